@@ -0,0 +1,97 @@
+package pginfo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Percona-Lab/pt-pg-summary/models"
+	"github.com/hashicorp/go-version"
+)
+
+// Number of WAL files waiting to be archived to flag the archiving as lagging behind
+const readyFilesWarning = 100
+
+// Archiving has the WAL archiving status
+type Archiving struct {
+	*models.ArchiverStatus
+	// WAL files waiting to be archived. -1 if unknown
+	ReadyFiles int
+	// True if the last failure is more recent than the last archived WAL
+	Failing bool
+	// True if there are too many WAL files waiting to be archived
+	Lagging bool
+}
+
+func (i *PGInfo) collectArchiving(db models.XODB) (*Archiving, error) {
+	version15, _ := version.NewVersion("15.0.0")
+
+	inBackup, backupStart := "pg_is_in_backup()", "pg_backup_start_time()"
+	if !i.ServerVersion.LessThan(version15) {
+		inBackup, backupStart = "false", "NULL::timestamptz"
+	}
+	status, err := models.GetArchiverStatus(db, inBackup, backupStart)
+	if err != nil {
+		return nil, err
+	}
+
+	archiving := &Archiving{ArchiverStatus: status, ReadyFiles: -1}
+	archiving.Failing = status.LastFailedTime.Valid &&
+		(!status.LastArchivedTime.Valid || status.LastFailedTime.Time.After(status.LastArchivedTime.Time))
+
+	archiving.ReadyFiles = i.readyFiles(db)
+	archiving.Lagging = archiving.ReadyFiles >= readyFilesWarning
+
+	return archiving, nil
+}
+
+// readyFiles returns the number of WAL files waiting to be archived or -1 if it is unknown. Before
+// PostgreSQL 12, or without the pg_monitor role, the archive_status directory is read only if the
+// server runs on this host, otherwise we could count the files of another server.
+func (i *PGInfo) readyFiles(db models.XODB) int {
+	version10, _ := version.NewVersion("10.0.0")
+	version12, _ := version.NewVersion("12.0.0")
+
+	if !i.ServerVersion.LessThan(version12) {
+		ready, err := models.GetArchiveReadyFiles(db)
+		if err == nil {
+			return int(ready.ReadyFiles)
+		}
+		i.logger.Warnf("Cannot list the WAL archive status directory: %s", err)
+	}
+	if !i.isLocalConnection() {
+		return -1
+	}
+
+	walDir := "pg_wal"
+	if i.ServerVersion.LessThan(version10) {
+		walDir = "pg_xlog"
+	}
+	for _, setting := range i.Settings {
+		if setting.Name == "data_directory" {
+			return countReadyFiles(filepath.Join(setting.Setting, walDir, "archive_status"))
+		}
+	}
+	return -1
+}
+
+// countReadyFiles returns the number of .ready files in the archive_status directory or -1 if the
+// directory cannot be read, usually because the server is not running on this host.
+func countReadyFiles(dir string) int {
+	d, err := os.Open(dir)
+	if err != nil {
+		return -1
+	}
+	defer d.Close()
+	names, err := d.Readdirnames(-1)
+	if err != nil {
+		return -1
+	}
+	count := 0
+	for _, name := range names {
+		if strings.HasSuffix(name, ".ready") {
+			count++
+		}
+	}
+	return count
+}
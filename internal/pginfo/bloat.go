@@ -0,0 +1,47 @@
+package pginfo
+
+import (
+	"github.com/Percona-Lab/pt-pg-summary/models"
+)
+
+// Bloat has the top N bloated tables and btree indexes of a database.
+// Tables and Indexes are statistical estimations based on pg_stats. ExactTables has the figures
+// from pgstattuple_approx for the largest tables and it is only collected if the pgstattuple
+// extension is installed and the exact bloat collection was enabled.
+type Bloat struct {
+	Tables      []*models.TableBloat
+	Indexes     []*models.IndexBloat
+	ExactTables []*models.ExactTableBloat
+}
+
+func (i *PGInfo) collectBloat(db models.XODB, dbName string) error {
+	var err error
+	bloat := &Bloat{}
+
+	if bloat.Tables, err = models.GetTableBloats(db, i.topCount); err != nil {
+		return err
+	}
+	if bloat.Indexes, err = models.GetIndexBloats(db, i.topCount); err != nil {
+		return err
+	}
+	i.Bloat[dbName] = bloat
+
+	if !i.exactBloat {
+		return nil
+	}
+
+	installed, err := i.isExtensionInstalled(db, "pgstattuple")
+	if err != nil {
+		return err
+	}
+	if !installed {
+		i.logger.Infof("pgstattuple is not installed in the %s database. Skipping exact bloat collection", dbName)
+		return nil
+	}
+	if bloat.ExactTables, err = models.GetExactTableBloats(db, i.topCount); err != nil {
+		// pgstattuple_approx needs special privileges. Keep the estimations anyway.
+		i.logger.Warnf("Cannot get the exact bloat for the %s database: %s", dbName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package pginfo
+
+import (
+	"strings"
+
+	"github.com/Percona-Lab/pt-pg-summary/models"
+	"github.com/lib/pq"
+)
+
+// TdeKeyProvider is a pg_tde key provider. Scope is database or global.
+type TdeKeyProvider struct {
+	*models.TdeKeyProviders
+	Scope string
+}
+
+// EncryptedTables has the transparent data encryption status of a database
+type EncryptedTables struct {
+	Extension    *models.Extensions // nil if pg_tde is not installed
+	Preloaded    bool               // true if pg_tde is in shared_preload_libraries
+	KeyProviders []*TdeKeyProvider
+	Encrypted    []*models.EncryptionStatus
+	// Tables and materialized views not encrypted in the schemas having encrypted relations
+	NotEncrypted []*models.EncryptionStatus
+}
+
+// collectEncryptedTables must run after the extensions of the database were collected
+func (i *PGInfo) collectEncryptedTables(db models.XODB, dbName string) error {
+	et := &EncryptedTables{}
+	i.EncryptedTables[dbName] = et
+
+	for _, setting := range i.Settings {
+		if setting.Name != "shared_preload_libraries" {
+			continue
+		}
+		for _, lib := range strings.Split(setting.Setting, ",") {
+			if strings.TrimSpace(lib) == "pg_tde" {
+				et.Preloaded = true
+			}
+		}
+	}
+
+	for _, ext := range i.Extensions[dbName] {
+		if ext.Extname == "pg_tde" {
+			et.Extension = ext
+		}
+	}
+	if et.Extension == nil {
+		return nil
+	}
+
+	functions, err := models.GetTdeKeyProviderFunctions(db, et.Extension.Schemaname)
+	if err != nil {
+		return err
+	}
+	for _, f := range functions {
+		providers, err := models.GetTdeKeyProviders(db, pq.QuoteIdentifier(et.Extension.Schemaname)+"."+
+			pq.QuoteIdentifier(f.Proname))
+		if err != nil {
+			// Listing the global key providers can require more privileges
+			i.logger.Warnf("Cannot list the pg_tde key providers of the %s database using %s: %s", dbName, f.Proname, err)
+			continue
+		}
+		scope := "database"
+		if strings.Contains(f.Proname, "global") {
+			scope = "global"
+		}
+		for _, p := range providers {
+			et.KeyProviders = append(et.KeyProviders, &TdeKeyProvider{TdeKeyProviders: p, Scope: scope})
+		}
+	}
+
+	relations, err := models.GetEncryptionStatus(db)
+	if err != nil {
+		return err
+	}
+	for _, r := range relations {
+		switch {
+		case r.Encrypted:
+			et.Encrypted = append(et.Encrypted, r)
+		case r.Kind != "index":
+			et.NotEncrypted = append(et.NotEncrypted, r)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package pginfo
+
+import (
+	"github.com/Percona-Lab/pt-pg-summary/models"
+)
+
+// ServerExtensions has the extensions available in the server and the libraries preloaded
+type ServerExtensions struct {
+	Available              []*models.AvailableExtensions
+	SharedPreloadLibraries string
+}
+
+func (i *PGInfo) collectServerExtensions(db models.XODB) (*ServerExtensions, error) {
+	available, err := models.GetAvailableExtensions(db)
+	if err != nil {
+		return nil, err
+	}
+	extensions := &ServerExtensions{Available: available}
+	for _, setting := range i.Settings {
+		if setting.Name == "shared_preload_libraries" {
+			extensions.SharedPreloadLibraries = setting.Setting
+		}
+	}
+	return extensions, nil
+}
+
+// NotInstalledExtensions returns the available extensions not installed in any of the databases
+// the information was collected from.
+func (i *PGInfo) NotInstalledExtensions() []*models.AvailableExtensions {
+	notInstalled := []*models.AvailableExtensions{}
+	if i.ServerExtensions == nil {
+		return notInstalled
+	}
+
+	installed := make(map[string]bool)
+	for _, extensions := range i.Extensions {
+		for _, ext := range extensions {
+			installed[ext.Extname] = true
+		}
+	}
+	for _, ext := range i.ServerExtensions.Available {
+		if !installed[ext.Name] {
+			notInstalled = append(notInstalled, ext)
+		}
+	}
+	return notInstalled
+}
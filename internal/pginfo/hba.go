@@ -0,0 +1,412 @@
+package pginfo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Percona-Lab/pt-pg-summary/models"
+	"github.com/hashicorp/go-version"
+	"github.com/lib/pq"
+)
+
+// HbaRule is a pg_hba.conf rule. Address is in CIDR notation when the rule has a netmask.
+type HbaRule struct {
+	File       string // Empty if the rules come from pg_hba_file_rules before PostgreSQL 15
+	LineNumber int64
+	Type       string
+	Databases  []string
+	Users      []string
+	Address    string
+	Method     string
+	Options    string
+	Error      string
+	Warnings   []string
+	ShadowedBy string // Location of an earlier rule matching all the connections of this rule
+
+	databases     []hbaItem
+	users         []hbaItem
+	addressQuoted bool
+}
+
+// hbaItem is an item of a pg_hba.conf field. Quoted items are never keywords, like all or sameuser.
+type hbaItem struct {
+	name   string
+	quoted bool
+}
+
+func (it hbaItem) isKeyword(keyword string) bool {
+	return !it.quoted && it.name == keyword
+}
+
+// sameAs returns true if both items match the same names. A quoted name is the same as the
+// unquoted name unless the unquoted one is a keyword or a group (+) or file (@) reference.
+func (it hbaItem) sameAs(other hbaItem) bool {
+	if it.name != other.name {
+		return false
+	}
+	if it.quoted == other.quoted {
+		return true
+	}
+	switch it.name {
+	case "all", "sameuser", "samerole", "samegroup", "replication":
+		return false
+	}
+	return !strings.HasPrefix(it.name, "+") && !strings.HasPrefix(it.name, "@")
+}
+
+// Location returns the line of the rule, prefixed by the file name if it is known
+func (r *HbaRule) Location() string {
+	if r.File == "" {
+		return fmt.Sprintf("%d", r.LineNumber)
+	}
+	return fmt.Sprintf("%s:%d", filepath.Base(r.File), r.LineNumber)
+}
+
+// HbaRules has the pg_hba.conf rules and where they were read from
+type HbaRules struct {
+	Source string // pg_hba_file_rules or the hba_file path
+	Rules  []*HbaRule
+}
+
+func (i *PGInfo) collectHbaRules(db models.XODB) (*HbaRules, error) {
+	version10, _ := version.NewVersion("10.0.0")
+	version15, _ := version.NewVersion("15.0.0")
+
+	if !i.ServerVersion.LessThan(version10) {
+		// Since PostgreSQL 15 the rules can come from included files and line_number is per file.
+		// Rules with errors have no rule_number.
+		fileName, orderBy := "''", "line_number"
+		if !i.ServerVersion.LessThan(version15) {
+			fileName, orderBy = "COALESCE(file_name, '')", "rule_number NULLS LAST, file_name, line_number"
+		}
+		rows, err := models.GetHbaRules(db, fileName, orderBy)
+		if err == nil {
+			rules := make([]*HbaRule, 0, len(rows))
+			for _, r := range rows {
+				rule := &HbaRule{
+					File:       r.FileName,
+					LineNumber: r.LineNumber,
+					Type:       r.Type,
+					Address:    hbaAddress(r.Address, r.Netmask),
+					Method:     r.AuthMethod,
+					Options:    r.Options,
+					Error:      r.Error,
+				}
+				rule.setDatabases(splitHbaList(r.Database))
+				rule.setUsers(splitHbaList(r.UserName))
+				rules = append(rules, rule)
+			}
+			analyzeHbaRules(rules)
+			return &HbaRules{Source: "pg_hba_file_rules", Rules: rules}, nil
+		}
+		if pqErr, ok := err.(*pq.Error); !ok || pqErr.Code != insufficientPrivilege {
+			return nil, err
+		}
+		i.logger.Warn("pg_hba_file_rules is not readable. Trying to read the hba_file")
+	}
+
+	hbaFile := ""
+	for _, setting := range i.Settings {
+		if setting.Name == "hba_file" {
+			hbaFile = setting.Setting
+		}
+	}
+	if hbaFile == "" {
+		i.logger.Warn("Cannot get the hba_file location. pg_hba.conf rules will not be checked")
+		return nil, nil
+	}
+	// The same path on this host can belong to another server
+	if !i.isLocalConnection() {
+		i.logger.Warnf("Cannot read %s from a remote server. pg_hba.conf rules will not be checked", hbaFile)
+		return nil, nil
+	}
+
+	f, err := os.Open(hbaFile)
+	if err != nil {
+		i.logger.Warnf("Cannot read %s. pg_hba.conf rules will not be checked: %s", hbaFile, err)
+		return nil, nil
+	}
+	defer f.Close()
+	rules, err := parseHbaFile(f)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		rule.File = hbaFile
+	}
+	analyzeHbaRules(rules)
+	return &HbaRules{Source: hbaFile, Rules: rules}, nil
+}
+
+// parseHbaFile returns the rules of a pg_hba.conf file. Included files are not followed.
+func parseHbaFile(r io.Reader) ([]*HbaRule, error) {
+	rules := []*HbaRule{}
+	scanner := bufio.NewScanner(r)
+	var lineNumber int64
+	for scanner.Scan() {
+		lineNumber++
+		if rule := parseHbaLine(scanner.Text(), lineNumber); rule != nil {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, scanner.Err()
+}
+
+// parseHbaLine returns nil for empty and comment lines
+func parseHbaLine(line string, lineNumber int64) *HbaRule {
+	fields := hbaFields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	rule := &HbaRule{LineNumber: lineNumber, Type: fields[0][0].name}
+	switch rule.Type {
+	case "local", "host", "hostssl", "hostnossl", "hostgssenc", "hostnogssenc":
+	case "include", "include_if_exists", "include_dir":
+		rule.Error = "included files are not checked"
+		return rule
+	default:
+		rule.Error = fmt.Sprintf("invalid connection type %q", rule.Type)
+		return rule
+	}
+
+	methodField := 3
+	if rule.Type != "local" {
+		methodField = 4
+	}
+	if len(fields) <= methodField {
+		rule.Error = "end-of-line before authentication method"
+		return rule
+	}
+	rule.setDatabases(fields[1])
+	rule.setUsers(fields[2])
+	if rule.Type != "local" {
+		address, netmask := fields[3][0].name, ""
+		rule.addressQuoted = fields[3][0].quoted
+		// The netmask can be in a separate field
+		if !strings.Contains(address, "/") && net.ParseIP(address) != nil {
+			netmask = fields[4][0].name
+			methodField++
+			if len(fields) <= methodField {
+				rule.Error = "end-of-line before authentication method"
+				return rule
+			}
+		}
+		rule.Address = hbaAddress(address, netmask)
+	}
+	rule.Method = fields[methodField][0].name
+	options := []string{}
+	for _, field := range fields[methodField+1:] {
+		options = append(options, strings.Join(hbaNames(field), ","))
+	}
+	rule.Options = strings.Join(options, " ")
+
+	return rule
+}
+
+// hbaFields splits a pg_hba.conf line in fields. Each field is a comma separated list of items
+// that can be double quoted.
+func hbaFields(line string) [][]hbaItem {
+	fields := [][]hbaItem{}
+	field, item := []hbaItem{}, hbaItem{}
+	inQuotes, pending := false, false
+
+	endItem := func() {
+		field = append(field, item)
+		item, pending = hbaItem{}, false
+	}
+	endField := func() {
+		if pending {
+			endItem()
+		}
+		if len(field) > 0 {
+			fields = append(fields, field)
+		}
+		field, item, pending = []hbaItem{}, hbaItem{}, false
+	}
+
+	for _, c := range line {
+		switch {
+		case c == '"':
+			inQuotes = !inQuotes
+			item.quoted = true
+			pending = true
+		case inQuotes:
+			item.name += string(c)
+		case c == '#':
+			endField()
+			return fields
+		case c == ',':
+			endItem()
+		case c == ' ' || c == '\t':
+			endField()
+		default:
+			item.name += string(c)
+			pending = true
+		}
+	}
+	endField()
+	return fields
+}
+
+// splitHbaList splits a list of pg_hba_file_rules. Items between double quotes are literal names.
+func splitHbaList(list string) []hbaItem {
+	if list == "" {
+		return nil
+	}
+	items := []hbaItem{}
+	for _, name := range strings.Split(list, ",") {
+		if len(name) > 1 && strings.HasPrefix(name, `"`) && strings.HasSuffix(name, `"`) {
+			items = append(items, hbaItem{name: name[1 : len(name)-1], quoted: true})
+			continue
+		}
+		items = append(items, hbaItem{name: name})
+	}
+	return items
+}
+
+// hbaNames returns the names of the items, quoting the ones that would be keywords without quotes
+func hbaNames(items []hbaItem) []string {
+	names := make([]string, 0, len(items))
+	for _, it := range items {
+		if it.quoted && !it.sameAs(hbaItem{name: it.name}) {
+			names = append(names, `"`+it.name+`"`)
+			continue
+		}
+		names = append(names, it.name)
+	}
+	return names
+}
+
+func (r *HbaRule) setDatabases(items []hbaItem) {
+	r.databases = items
+	r.Databases = hbaNames(items)
+}
+
+func (r *HbaRule) setUsers(items []hbaItem) {
+	r.users = items
+	r.Users = hbaNames(items)
+}
+
+// hbaAddress returns the address in CIDR notation if there is a netmask
+func hbaAddress(address, netmask string) string {
+	if netmask == "" {
+		return address
+	}
+	mask := net.ParseIP(netmask)
+	if mask == nil {
+		return address
+	}
+	if m4 := mask.To4(); m4 != nil {
+		mask = m4
+	}
+	ones, bits := net.IPMask(mask).Size()
+	if bits == 0 { // not a canonical mask
+		return address + " " + netmask
+	}
+	return fmt.Sprintf("%s/%d", address, ones)
+}
+
+// analyzeHbaRules flags insecure methods, rules open to any address and rules that will never
+// be used because an earlier rule matches all their connections.
+func analyzeHbaRules(rules []*HbaRule) {
+	for n, rule := range rules {
+		if rule.Error != "" {
+			continue
+		}
+		switch rule.Method {
+		case "trust":
+			rule.Warnings = append(rule.Warnings, "trust authentication")
+		case "password":
+			rule.Warnings = append(rule.Warnings, "clear text password")
+		case "md5":
+			rule.Warnings = append(rule.Warnings, "MD5 password")
+		}
+		if rule.Type != "local" && !rule.addressQuoted && isAnyAddress(rule.Address) {
+			rule.Warnings = append(rule.Warnings, "open to any address")
+		}
+		for _, earlier := range rules[:n] {
+			if earlier.Error == "" && hbaRuleCovers(earlier, rule) {
+				rule.ShadowedBy = earlier.Location()
+				break
+			}
+		}
+	}
+}
+
+func isAnyAddress(address string) bool {
+	if address == "all" {
+		return true
+	}
+	_, network, err := net.ParseCIDR(address)
+	if err != nil {
+		return false
+	}
+	ones, _ := network.Mask.Size()
+	return ones == 0
+}
+
+// hbaRuleCovers returns true if rule a matches all the connections rule b matches
+func hbaRuleCovers(a, b *HbaRule) bool {
+	typeCovers := a.Type == b.Type || (a.Type == "host" && b.Type != "local")
+	if !typeCovers {
+		return false
+	}
+	for _, db := range b.databases {
+		// all does not match replication connections
+		if !hbaListHas(a.databases, db) && (!hbaListHasKeyword(a.databases, "all") || db.isKeyword("replication")) {
+			return false
+		}
+	}
+	for _, user := range b.users {
+		if !hbaListHas(a.users, user) && !hbaListHasKeyword(a.users, "all") {
+			return false
+		}
+	}
+	if a.addressQuoted || b.addressQuoted { // quoted addresses are host names
+		return a.addressQuoted == b.addressQuoted && a.Address == b.Address
+	}
+	return hbaAddressCovers(a.Address, b.Address)
+}
+
+func hbaListHas(list []hbaItem, item hbaItem) bool {
+	for _, i := range list {
+		if i.sameAs(item) {
+			return true
+		}
+	}
+	return false
+}
+
+func hbaListHasKeyword(list []hbaItem, keyword string) bool {
+	for _, i := range list {
+		if i.isKeyword(keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// hbaAddressCovers returns true if address a includes address b. Host names, samehost and samenet
+// only include themselves.
+func hbaAddressCovers(a, b string) bool {
+	if a == b || a == "all" {
+		return true
+	}
+	_, na, err := net.ParseCIDR(a)
+	if err != nil {
+		return false
+	}
+	_, nb, err := net.ParseCIDR(b)
+	if err != nil {
+		return false
+	}
+	onesA, bitsA := na.Mask.Size()
+	onesB, bitsB := nb.Mask.Size()
+	return bitsA == bitsB && onesA <= onesB && na.Contains(nb.IP)
+}
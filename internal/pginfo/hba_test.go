@@ -0,0 +1,79 @@
+package pginfo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testHbaFile = `# TYPE  DATABASE        USER            ADDRESS                 METHOD
+local   all             postgres                                peer
+host    all             all             127.0.0.1/32            trust
+host    "my db",shop    app             10.0.0.0 255.0.0.0      md5 # comment
+hostssl shop            app             10.1.0.0/16             scram-sha-256
+host    replication     repl            0.0.0.0/0               scram-sha-256
+hostssl replication     repl            10.0.0.1/32             scram-sha-256
+hots    all             all             ::1/128                 trust
+host    all             all             10.0.0.0/8
+host    "all"           app             10.0.0.0/8              scram-sha-256
+host    sales           app             10.2.0.0/16             scram-sha-256
+host    "all"           "sameuser"      "all"                   scram-sha-256
+host    "all"           app             10.3.0.0/16             scram-sha-256
+`
+
+func TestParseHbaFile(t *testing.T) {
+	rules, err := parseHbaFile(strings.NewReader(testHbaFile))
+	if err != nil {
+		t.Fatalf("Cannot parse the hba file: %s", err)
+	}
+	analyzeHbaRules(rules)
+	if len(rules) != 12 {
+		t.Fatalf("Invalid number of rules. Want 12, got %d", len(rules))
+	}
+
+	want := &HbaRule{LineNumber: 4, Type: "host", Databases: []string{"my db", "shop"}, Users: []string{"app"},
+		Address: "10.0.0.0/8", Method: "md5", Options: "", Warnings: []string{"MD5 password"},
+		databases: []hbaItem{{"my db", true}, {"shop", false}}, users: []hbaItem{{"app", false}}}
+	if !reflect.DeepEqual(rules[2], want) {
+		t.Errorf("Invalid rule.\nWant %+v\ngot  %+v", want, rules[2])
+	}
+
+	tests := []struct {
+		line       int64
+		shadowedBy string
+		warnings   []string
+		err        string
+	}{
+		{2, "", nil, ""},
+		{3, "", []string{"trust authentication"}, ""},
+		{5, "4", nil, ""},
+		{6, "", []string{"open to any address"}, ""},
+		{7, "6", nil, ""},
+		{8, "", nil, `invalid connection type "hots"`},
+		{9, "", nil, "end-of-line before authentication method"},
+		// Quoted keywords are literal names
+		{10, "", nil, ""},
+		{11, "", nil, ""},
+		{12, "", nil, ""},
+		{13, "10", nil, ""},
+	}
+	rulesByLine := make(map[int64]*HbaRule)
+	for _, rule := range rules {
+		rulesByLine[rule.LineNumber] = rule
+	}
+	if got := rulesByLine[12].Databases; !reflect.DeepEqual(got, []string{`"all"`}) {
+		t.Errorf("Quoted keywords must be shown with quotes. Got %v", got)
+	}
+	for _, test := range tests {
+		rule := rulesByLine[test.line]
+		if rule.ShadowedBy != test.shadowedBy {
+			t.Errorf("Line %d: invalid shadowing rule. Want %q, got %q", test.line, test.shadowedBy, rule.ShadowedBy)
+		}
+		if !reflect.DeepEqual(rule.Warnings, test.warnings) {
+			t.Errorf("Line %d: invalid warnings. Want %v, got %v", test.line, test.warnings, rule.Warnings)
+		}
+		if rule.Error != test.err {
+			t.Errorf("Line %d: invalid error. Want %q, got %q", test.line, test.err, rule.Error)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package pginfo
+
+import (
+	"github.com/Percona-Lab/pt-pg-summary/models"
+)
+
+// IndexHealth has the index findings of a database, sorted by size
+type IndexHealth struct {
+	Unused    []*models.UnusedIndexes
+	Redundant []*models.RedundantIndexes
+	Invalid   []*models.InvalidIndexes
+	MissingFK []*models.MissingFkIndexes
+}
+
+func (i *PGInfo) collectIndexHealth(db models.XODB, dbName string) error {
+	var err error
+	health := &IndexHealth{}
+
+	if health.Unused, err = models.GetUnusedIndexes(db, i.topCount); err != nil {
+		return err
+	}
+	if health.Redundant, err = models.GetRedundantIndexes(db, i.topCount); err != nil {
+		return err
+	}
+	if health.Invalid, err = models.GetInvalidIndexes(db, i.topCount); err != nil {
+		return err
+	}
+	if health.MissingFK, err = models.GetMissingFkIndexes(db, i.topCount); err != nil {
+		return err
+	}
+
+	i.IndexHealth[dbName] = health
+	return nil
+}
@@ -0,0 +1,110 @@
+package pginfo
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Percona-Lab/pt-pg-summary/models"
+	"github.com/hashicorp/go-version"
+)
+
+// BlockingNode is a session in a blocking lock tree. Sessions at Level 0 are the ones holding the
+// locks and the sessions below them (with a greater Level) are the ones waiting for those locks.
+// Prefix and Indent are used to print the tree.
+type BlockingNode struct {
+	Level   int
+	Prefix  string
+	Indent  string
+	Session *models.BlockingLocks
+}
+
+func (i *PGInfo) collectBlockingTrees(db models.XODB) error {
+	version96, _ := version.NewVersion("9.6.0")
+	if i.ServerVersion.LessThan(version96) {
+		i.logger.Info("pg_blocking_pids is not available before PostgreSQL 9.6. Skipping blocking locks collection")
+		return nil
+	}
+
+	rows, err := models.GetBlockingLocks(db, queryTextLength)
+	if err != nil {
+		return err
+	}
+	i.BlockingTrees = blockingTrees(rows)
+	return nil
+}
+
+// blockingTrees returns the blocking trees in print order. A session waiting for more than one
+// blocker appears under each of them.
+func blockingTrees(rows []*models.BlockingLocks) []*BlockingNode {
+	sessions := make(map[int]*models.BlockingLocks)
+	waiters := make(map[int][]int) // blocker pid -> pids waiting for it
+	isWaiting := make(map[int]bool)
+	pids := []int{}
+
+	for _, row := range rows {
+		if _, ok := sessions[row.Pid]; !ok {
+			sessions[row.Pid] = row
+			pids = append(pids, row.Pid)
+		}
+		if row.BlockedBy.Valid {
+			blocker := int(row.BlockedBy.Int64)
+			waiters[blocker] = append(waiters[blocker], row.Pid)
+			isWaiting[row.Pid] = true
+		}
+	}
+
+	// Blockers not in pg_stat_activity. pg_blocking_pids returns 0 for prepared transactions.
+	for blocker := range waiters {
+		if _, ok := sessions[blocker]; ok {
+			continue
+		}
+		s := &models.BlockingLocks{Pid: blocker}
+		if blocker == 0 {
+			s.State.String, s.State.Valid = "prepared transaction", true
+		}
+		sessions[blocker] = s
+		pids = append(pids, blocker)
+	}
+	sort.Ints(pids)
+
+	nodes := []*BlockingNode{}
+	printed := make(map[int]bool)
+	path := make(map[int]bool)
+
+	var walk func(pid, level int)
+	walk = func(pid, level int) {
+		prefix := ""
+		if level > 0 {
+			prefix = strings.Repeat("    ", level-1) + "`-> "
+		}
+		nodes = append(nodes, &BlockingNode{
+			Level:   level,
+			Prefix:  prefix,
+			Indent:  strings.Repeat(" ", len(prefix)),
+			Session: sessions[pid],
+		})
+		printed[pid] = true
+		path[pid] = true
+		for _, waiter := range waiters[pid] {
+			if path[waiter] { // lock cycle not yet detected as a deadlock
+				continue
+			}
+			walk(waiter, level+1)
+		}
+		delete(path, pid)
+	}
+
+	for _, pid := range pids {
+		if !isWaiting[pid] {
+			walk(pid, 0)
+		}
+	}
+	// Sessions waiting for each other have no root
+	for _, pid := range pids {
+		if !printed[pid] {
+			walk(pid, 0)
+		}
+	}
+
+	return nodes
+}
@@ -0,0 +1,43 @@
+package pginfo
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/Percona-Lab/pt-pg-summary/models"
+)
+
+func TestBlockingTrees(t *testing.T) {
+	blockedBy := func(pid int64) sql.NullInt64 { return sql.NullInt64{Int64: pid, Valid: true} }
+	rows := []*models.BlockingLocks{
+		{Pid: 10},
+		{Pid: 11, BlockedBy: blockedBy(10)},
+		{Pid: 12, BlockedBy: blockedBy(11)},
+		{Pid: 13, BlockedBy: blockedBy(0)},
+		{Pid: 20, BlockedBy: blockedBy(21)},
+		{Pid: 21, BlockedBy: blockedBy(20)},
+	}
+
+	want := []struct {
+		pid   int
+		level int
+	}{
+		{0, 0}, {13, 1},
+		{10, 0}, {11, 1}, {12, 2},
+		{20, 0}, {21, 1},
+	}
+
+	nodes := blockingTrees(rows)
+	if len(nodes) != len(want) {
+		t.Fatalf("Invalid number of nodes. Want %d, got %d", len(want), len(nodes))
+	}
+	for i, node := range nodes {
+		if node.Session.Pid != want[i].pid || node.Level != want[i].level {
+			t.Errorf("Invalid node #%d. Want pid %d at level %d, got pid %d at level %d",
+				i, want[i].pid, want[i].level, node.Session.Pid, node.Level)
+		}
+	}
+	if nodes[0].Session.State.String != "prepared transaction" {
+		t.Errorf("Blocker with pid 0 must be reported as a prepared transaction")
+	}
+}
@@ -0,0 +1,52 @@
+package pginfo
+
+import (
+	"github.com/Percona-Lab/pt-pg-summary/models"
+	"github.com/hashicorp/go-version"
+)
+
+// LogicalReplication has the publications and subscriptions of a database
+type LogicalReplication struct {
+	Publications      []*models.Publications
+	PublicationTables []*models.PublicationTables // All the published tables, not limited by --top
+	Subscriptions     []*models.Subscriptions
+	SyncingTables     []*models.SubscriptionSyncTables // Tables still in the initial sync
+}
+
+func (i *PGInfo) collectLogicalReplication(db models.XODB, dbName string) error {
+	version10, _ := version.NewVersion("10.0.0")
+	version11, _ := version.NewVersion("11.0.0")
+	version15, _ := version.NewVersion("15.0.0")
+	if i.ServerVersion.LessThan(version10) {
+		return nil
+	}
+
+	var err error
+	lr := &LogicalReplication{}
+
+	pubtruncate := "p.pubtruncate"
+	if i.ServerVersion.LessThan(version11) {
+		pubtruncate = "false"
+	}
+	if lr.Publications, err = models.GetPublications(db, pubtruncate); err != nil {
+		return err
+	}
+	if lr.PublicationTables, err = models.GetPublicationTables(db); err != nil {
+		return err
+	}
+
+	applyErrors, syncErrors := "NULL::bigint", "NULL::bigint"
+	if !i.ServerVersion.LessThan(version15) {
+		applyErrors = "(SELECT ss.apply_error_count FROM pg_stat_subscription_stats ss WHERE ss.subid = s.oid)"
+		syncErrors = "(SELECT ss.sync_error_count FROM pg_stat_subscription_stats ss WHERE ss.subid = s.oid)"
+	}
+	if lr.Subscriptions, err = models.GetSubscriptions(db, applyErrors, syncErrors); err != nil {
+		return err
+	}
+	if lr.SyncingTables, err = models.GetSubscriptionSyncTables(db, i.topCount); err != nil {
+		return err
+	}
+
+	i.LogicalReplication[dbName] = lr
+	return nil
+}
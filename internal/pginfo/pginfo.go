@@ -3,6 +3,7 @@ package pginfo
 import (
 	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/Percona-Lab/pt-pg-summary/models"
@@ -12,6 +13,16 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// ApplicationName is the application_name used in the connections to the server
+const ApplicationName = "pt-pg-summary"
+
+// queryTextLength is the max length of the query texts read from the server
+const queryTextLength = 100
+
+// statioMinReads is the number of blocks a relation must have read from disk to be in the worst
+// cache hit ratio lists, so relations barely read don't fill them
+const statioMinReads = 1000
+
 // Process contains PostgreSQL process information
 type Process struct {
 	PID     int32
@@ -21,20 +32,53 @@ type Process struct {
 // PGInfo has exported fields containing the data collected.
 // Fields are exported to be able to use them when printing the templates
 type PGInfo struct {
+	BlockingTrees      []*BlockingNode
 	ClusterInfo        []*models.ClusterInfo
 	ConnectedClients   []*models.ConnectedClients
-	DatabaseWaitEvents []*models.DatabaseWaitEvents
+	ConnectionSecurity *ConnectionSecurity                     // TLS settings and sessions encryption
+	DatabaseWaitEvents map[string][]*models.DatabaseWaitEvents // Wait events per relation per database
+	DatabaseWraparound []*DatabaseWraparoundRisk
+	EncryptedTables    map[string]*EncryptedTables     // Transparent data encryption status per database
+	Extensions         map[string][]*models.Extensions // Installed extensions per database
 	AllDatabases       []*models.Databases
+	Archiving          *Archiving
+	Bloat              map[string]*Bloat // Top bloated tables and indexes per database
 	GlobalWaitEvents   []*models.GlobalWaitEvents
+	HbaRules           *HbaRules // nil if pg_hba.conf cannot be read
+	IdleInTransaction  []*models.IdleInTransaction
+	IndexHealth        map[string]*IndexHealth            // Unused, redundant, invalid and missing FK indexes per database
+	LargestTables      map[string][]*models.LargestTables // Largest tables per database
+	LogicalReplication map[string]*LogicalReplication     // Publications and subscriptions per database
+	LongTransactions   []*models.LongTransactions
 	PortAndDatadir     *models.PortAndDatadir
+	PublicSchema       map[string]*models.PublicSchemaPrivileges // CREATE privilege on the public schema per database
+	ReplicationSlots   []*models.ReplicationSlots
+	RolesAudit         *RolesAudit
 	SlaveHosts96       []*models.SlaveHosts96
+	StandbyStatus      *StandbyStatus // nil if the server is not a standby
 	SlaveHosts10       []*models.SlaveHosts10
 	Tablespaces        []*models.Tablespaces
+	SchemaInventory    map[string]*models.SchemaInventory // Schema objects count per database
+	ServerExtensions   *ServerExtensions                  // Available extensions and preloaded libraries
+	Sequences          map[string][]*SequenceUsage        // Sequences closer to exhaustion per database
 	Settings           []*models.Setting
+	SettingsReport     *SettingsReport                       // Settings grouped by category
 	Counters           map[models.Name][]*models.Counters    // Counters per database
 	IndexCacheHitRatio map[string]*models.IndexCacheHitRatio // Indexes cache hit ratio per database
 	TableCacheHitRatio map[string]*models.TableCacheHitRatio // Tables cache hit ratio per database
-	TableAccess        map[string][]*models.TableAccess      // Table access per database
+	IndexStatio        map[string][]*models.IndexStatio      // Indexes with the most disk reads per database
+	IndexStatioByRatio map[string][]*models.IndexStatio      // Indexes with the worst cache hit ratio per database
+	TableStatio        map[string][]*models.TableStatio      // Tables with the most disk reads per database
+	TableStatioByRatio map[string][]*models.TableStatio      // Tables with the worst cache hit ratio per database
+	TableAccess        map[string][]*models.TableAccess      // Locks held per relation per database
+	TableActivity      map[string]*TableActivity             // Busiest tables during the sleep window per database
+	TableWraparound    map[string][]*TableWraparoundRisk     // Tables with the oldest relfrozenxid per database
+	TopStatements      *TopStatements                        // nil if pg_stat_statements is not installed
+	StatementsDatabase string                                // Database pg_stat_statements is read from
+	Topology           []*TopologyNode                       // nil if the replicas were not followed
+	VacuumHealth       map[string][]*models.VacuumHealth     // Tables with dead tuples per database
+	WaitEventsProfile  *WaitEventsProfile                    // Wait events sampled during the sleep window
+	XminHolders        []*models.XminHolders
 	ServerVersion      *version.Version
 	Sleep              int
 	Processes          []Process
@@ -45,6 +89,31 @@ type PGInfo struct {
 	// method in the models pkg
 	databases []string
 	logger    *logrus.Logger
+	// Host used to connect to the server. Files of the server are only read if it is a local host
+	host string
+	// 1st snapshot of pg_stat_user_tables per database, taken before the sleep window
+	tableActivity map[string]*tableActivitySnapshot
+	// 1st snapshot of the sequences last values per database, taken before the sleep window
+	sequences map[string]*sequencesSnapshot
+	// Number of rows to show in the top N sections
+	topCount int
+	// If true, pg_stat_statements counters are the diff between the beginning and the end of the sleep window
+	statementsDeltas bool
+	// Connection to the database having pg_stat_statements installed. nil to use the main connection
+	statementsDB models.XODB
+	// Seconds a session must be idle in transaction to be reported
+	idleInTransactionThreshold int
+	// Time between wait events samples during the sleep window
+	waitEventsInterval time.Duration
+	// Percentages of the wraparound hard limit to flag transaction ID and MultiXact ID ages
+	wraparoundWarning  float64
+	wraparoundCritical float64
+	// If true, use pgstattuple_approx (if installed) to get the exact bloat of the largest tables
+	exactBloat bool
+	// If true, list the tables encrypted by pg_tde
+	listEncryptedTables bool
+	// If true, report all the settings instead of only the ones different from their boot value
+	allSettings bool
 }
 
 // New returns a new PGInfo instance with a local logger instance
@@ -61,12 +130,37 @@ func new(db models.XODB, databases []string, sleep int, logger *logrus.Logger) (
 	var err error
 	info := &PGInfo{
 		databases:          databases,
+		Bloat:              make(map[string]*Bloat),
 		Counters:           make(map[models.Name][]*models.Counters),
+		DatabaseWaitEvents: make(map[string][]*models.DatabaseWaitEvents),
+		EncryptedTables:    make(map[string]*EncryptedTables),
+		Extensions:         make(map[string][]*models.Extensions),
+		IndexHealth:        make(map[string]*IndexHealth),
+		LargestTables:      make(map[string][]*models.LargestTables),
+		LogicalReplication: make(map[string]*LogicalReplication),
+		PublicSchema:       make(map[string]*models.PublicSchemaPrivileges),
+		SchemaInventory:    make(map[string]*models.SchemaInventory),
+		Sequences:          make(map[string][]*SequenceUsage),
 		TableAccess:        make(map[string][]*models.TableAccess),
+		TableActivity:      make(map[string]*TableActivity),
+		TableWraparound:    make(map[string][]*TableWraparoundRisk),
 		TableCacheHitRatio: make(map[string]*models.TableCacheHitRatio),
+		VacuumHealth:       make(map[string][]*models.VacuumHealth),
 		IndexCacheHitRatio: make(map[string]*models.IndexCacheHitRatio),
+		IndexStatio:        make(map[string][]*models.IndexStatio),
+		IndexStatioByRatio: make(map[string][]*models.IndexStatio),
+		TableStatio:        make(map[string][]*models.TableStatio),
+		TableStatioByRatio: make(map[string][]*models.TableStatio),
 		Sleep:              sleep,
 		logger:             logger,
+		topCount:           10,
+		tableActivity:      make(map[string]*tableActivitySnapshot),
+		sequences:          make(map[string]*sequencesSnapshot),
+
+		idleInTransactionThreshold: 60,
+		waitEventsInterval:         defaultWaitEventsInterval,
+		wraparoundWarning:          50,
+		wraparoundCritical:         75,
 	}
 
 	if info.AllDatabases, err = models.GetDatabases(db); err != nil {
@@ -110,24 +204,132 @@ func (i *PGInfo) DatabaseNames() []string {
 	return i.databases
 }
 
-// CollectPerDatabaseInfo collects information for a specific database
-func (i *PGInfo) CollectPerDatabaseInfo(db models.XODB, dbName string) (err error) {
+// SnapshotPerDatabaseInfo takes the 1st snapshot of the per database counters used to calculate
+// rates. It must be called before CollectGlobalInfo since the 2nd snapshot is taken by
+// CollectPerDatabaseInfo, after the sleep window. It also collects the extensions, used to find
+// the database to read pg_stat_statements from.
+func (i *PGInfo) SnapshotPerDatabaseInfo(db models.XODB, dbName string) []error {
+	errs := make([]error, 0)
+	var err error
+
+	i.logger.Info("Collecting Extensions information")
+	if i.Extensions[dbName], err = models.GetExtensions(db); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get Extensions for the %s database", dbName))
+	}
+
+	i.logger.Info("Collecting Table Activity information (1st pass)")
+	if err := i.snapshotTableActivity(db, dbName); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get Table Activity for the %s database", dbName))
+	}
+
+	i.logger.Info("Collecting Sequences information (1st pass)")
+	if err := i.snapshotSequences(db, dbName); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get Sequences for the %s database", dbName))
+	}
+
+	return errs
+}
+
+// CollectPerDatabaseInfo collects information for a specific database. A failing section does
+// not prevent the collection of the next ones.
+func (i *PGInfo) CollectPerDatabaseInfo(db models.XODB, dbName string) []error {
+	errs := make([]error, 0)
+	var err error
+
 	i.logger.Info("Collecting Table Access information")
 	if i.TableAccess[dbName], err = models.GetTableAccesses(db); err != nil {
-		return errors.Wrapf(err, "cannot get Table Accesses for the %s ibase", dbName)
+		errs = append(errs, errors.Wrapf(err, "cannot get Table Accesses for the %s ibase", dbName))
+	}
+
+	i.logger.Info("Collecting Table Activity information (2nd pass)")
+	if err = i.collectTableActivity(db, dbName); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get Table Activity for the %s database", dbName))
+	}
+
+	i.logger.Info("Collecting Sequences information (2nd pass)")
+	if err = i.collectSequences(db, dbName); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get Sequences for the %s database", dbName))
+	}
+
+	i.logger.Info("Collecting Database Wait Events information")
+	if i.DatabaseWaitEvents[dbName], err = models.GetDatabaseWaitEvents(db); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get Database Wait Events for the %s database", dbName))
 	}
 
 	i.logger.Info("Collecting Table Cache Hit Ratio information")
 	if i.TableCacheHitRatio[dbName], err = models.GetTableCacheHitRatio(db); err != nil {
-		return errors.Wrapf(err, "cannot get Table Cache Hit Ratios for the %s ibase", dbName)
+		errs = append(errs, errors.Wrapf(err, "cannot get Table Cache Hit Ratios for the %s ibase", dbName))
 	}
 
 	i.logger.Info("Collecting Index Cache Hit Ratio information")
 	if i.IndexCacheHitRatio[dbName], err = models.GetIndexCacheHitRatio(db); err != nil {
-		return errors.Wrapf(err, "cannot get Index Cache Hit Ratio for the %s ibase", dbName)
+		errs = append(errs, errors.Wrapf(err, "cannot get Index Cache Hit Ratio for the %s ibase", dbName))
 	}
 
-	return nil
+	i.logger.Info("Collecting per Table Cache Hit Ratio information")
+	if i.TableStatio[dbName], err = models.GetTableStatio(db, 0, false, i.topCount); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get per Table Cache Hit Ratios for the %s database", dbName))
+	}
+	if i.TableStatioByRatio[dbName], err = models.GetTableStatio(db, statioMinReads, true, i.topCount); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get worst Table Cache Hit Ratios for the %s database", dbName))
+	}
+
+	i.logger.Info("Collecting per Index Cache Hit Ratio information")
+	if i.IndexStatio[dbName], err = models.GetIndexStatio(db, 0, false, i.topCount); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get per Index Cache Hit Ratios for the %s database", dbName))
+	}
+	if i.IndexStatioByRatio[dbName], err = models.GetIndexStatio(db, statioMinReads, true, i.topCount); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get worst Index Cache Hit Ratios for the %s database", dbName))
+	}
+
+	i.logger.Info("Collecting Logical Replication information")
+	if err = i.collectLogicalReplication(db, dbName); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get Logical Replication for the %s database", dbName))
+	}
+
+	if i.listEncryptedTables {
+		i.logger.Info("Collecting Encrypted Tables information")
+		if err = i.collectEncryptedTables(db, dbName); err != nil {
+			errs = append(errs, errors.Wrapf(err, "cannot get Encrypted Tables for the %s database", dbName))
+		}
+	}
+
+	i.logger.Info("Collecting Public Schema Privileges information")
+	if i.PublicSchema[dbName], err = models.GetPublicSchemaPrivileges(db); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get Public Schema Privileges for the %s database", dbName))
+	}
+
+	i.logger.Info("Collecting Schema Inventory information")
+	if i.SchemaInventory[dbName], err = models.GetSchemaInventory(db); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get Schema Inventory for the %s database", dbName))
+	}
+
+	i.logger.Info("Collecting Largest Tables information")
+	if i.LargestTables[dbName], err = models.GetLargestTables(db, i.topCount); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get Largest Tables for the %s database", dbName))
+	}
+
+	i.logger.Info("Collecting Vacuum Health information")
+	if i.VacuumHealth[dbName], err = models.GetVacuumHealths(db, i.topCount); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get Vacuum Health for the %s database", dbName))
+	}
+
+	i.logger.Info("Collecting Tables Wraparound information")
+	if err = i.collectTableWraparound(db, dbName); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get Tables Wraparound for the %s database", dbName))
+	}
+
+	i.logger.Info("Collecting Bloat information")
+	if err = i.collectBloat(db, dbName); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get Bloat for the %s database", dbName))
+	}
+
+	i.logger.Info("Collecting Index Health information")
+	if err = i.collectIndexHealth(db, dbName); err != nil {
+		errs = append(errs, errors.Wrapf(err, "cannot get Index Health for the %s database", dbName))
+	}
+
+	return errs
 }
 
 // CollectGlobalInfo collects global information
@@ -149,6 +351,26 @@ func (i *PGInfo) CollectGlobalInfo(db models.XODB) []error {
 		}
 	}
 
+	statementsDB := db
+	if i.statementsDB != nil {
+		statementsDB = i.statementsDB
+	}
+	statementsInstalled, err := i.isExtensionInstalled(statementsDB, "pg_stat_statements")
+	if err != nil {
+		errs = append(errs, errors.Wrap(err, "Cannot check if pg_stat_statements is installed"))
+	}
+	var statements1 []*models.StatStatements
+	if statementsInstalled && i.statementsDeltas {
+		i.logger.Info("Collecting pg_stat_statements (1st pass)")
+		if statements1, err = i.getStatStatements(statementsDB); err != nil {
+			errs = append(errs, errors.Wrap(err, "Cannot get pg_stat_statements (1st run)"))
+		}
+	}
+
+	waitEventsCh := make(chan interface{}, 1)
+	i.logger.Infof("Sampling wait events every %v", i.waitEventsInterval)
+	go i.sampleWaitEvents(db, time.Duration(i.Sleep)*time.Second, i.waitEventsInterval, waitEventsCh)
+
 	go func() {
 		i.logger.Infof("Waiting %d seconds to read  counters", i.Sleep)
 		time.Sleep(time.Duration(i.Sleep) * time.Second)
@@ -166,9 +388,24 @@ func (i *PGInfo) CollectGlobalInfo(db models.XODB) []error {
 		errs = append(errs, errors.Wrap(err, "Cannot get the connected clients list"))
 	}
 
-	i.logger.Info("Collecting Database Wait Events information")
-	if i.DatabaseWaitEvents, err = models.GetDatabaseWaitEvents(db); err != nil {
-		errs = append(errs, errors.Wrap(err, "Cannot get databases wait events"))
+	i.logger.Info("Collecting Long Running Transactions information")
+	if i.LongTransactions, err = models.GetLongTransactions(db, queryTextLength, i.topCount); err != nil {
+		errs = append(errs, errors.Wrap(err, "Cannot get the long running transactions"))
+	}
+
+	i.logger.Info("Collecting Idle in Transaction Sessions information")
+	if i.IdleInTransaction, err = models.GetIdleInTransactions(db, queryTextLength, i.idleInTransactionThreshold); err != nil {
+		errs = append(errs, errors.Wrap(err, "Cannot get the sessions idle in transaction"))
+	}
+
+	i.logger.Info("Collecting Oldest Xmin Holders information")
+	if i.XminHolders, err = models.GetXminHolders(db, i.topCount); err != nil {
+		errs = append(errs, errors.Wrap(err, "Cannot get the oldest xmin holders"))
+	}
+
+	i.logger.Info("Collecting Blocking Locks information")
+	if err = i.collectBlockingTrees(db); err != nil {
+		errs = append(errs, errors.Wrap(err, "Cannot get the blocking locks"))
 	}
 
 	i.logger.Info("Collecting Global Wait Events information")
@@ -176,6 +413,11 @@ func (i *PGInfo) CollectGlobalInfo(db models.XODB) []error {
 		errs = append(errs, errors.Wrap(err, "Cannot get Global Wait Events"))
 	}
 
+	i.logger.Info("Collecting Databases Wraparound information")
+	if err = i.collectDatabaseWraparound(db); err != nil {
+		errs = append(errs, errors.Wrap(err, "Cannot get databases wraparound"))
+	}
+
 	i.logger.Info("Collecting Port and Data Dir information")
 	if i.PortAndDatadir, err = models.GetPortAndDatadir(db); err != nil {
 		errs = append(errs, errors.Wrap(err, "Cannot get Port and Dir"))
@@ -189,6 +431,8 @@ func (i *PGInfo) CollectGlobalInfo(db models.XODB) []error {
 	i.logger.Info("Collecting Instance Settings information")
 	if i.Settings, err = models.GetSettings(db); err != nil {
 		errs = append(errs, errors.Wrap(err, "Cannot get instance settings"))
+	} else {
+		i.SettingsReport = newSettingsReport(i.Settings, i.allSettings)
 	}
 
 	if i.ServerVersion.LessThan(version10) {
@@ -205,6 +449,41 @@ func (i *PGInfo) CollectGlobalInfo(db models.XODB) []error {
 		}
 	}
 
+	i.logger.Info("Collecting Available Extensions information")
+	if i.ServerExtensions, err = i.collectServerExtensions(db); err != nil {
+		errs = append(errs, errors.Wrap(err, "Cannot get available extensions"))
+	}
+
+	i.logger.Info("Collecting Connection Security information")
+	if i.ConnectionSecurity, err = i.collectConnectionSecurity(db); err != nil {
+		errs = append(errs, errors.Wrap(err, "Cannot get connection security"))
+	}
+
+	i.logger.Info("Collecting Roles information")
+	if i.RolesAudit, err = i.collectRolesAudit(db); err != nil {
+		errs = append(errs, errors.Wrap(err, "Cannot get roles"))
+	}
+
+	i.logger.Info("Collecting pg_hba.conf rules")
+	if i.HbaRules, err = i.collectHbaRules(db); err != nil {
+		errs = append(errs, errors.Wrap(err, "Cannot get pg_hba.conf rules"))
+	}
+
+	i.logger.Info("Collecting WAL Archiving information")
+	if i.Archiving, err = i.collectArchiving(db); err != nil {
+		errs = append(errs, errors.Wrap(err, "Cannot get WAL archiving status"))
+	}
+
+	i.logger.Info("Collecting Replication Slots information")
+	if i.ReplicationSlots, err = i.getReplicationSlots(db); err != nil {
+		errs = append(errs, errors.Wrap(err, "Cannot get replication slots"))
+	}
+
+	i.logger.Info("Collecting Standby Status information")
+	if i.StandbyStatus, err = i.collectStandbyStatus(db); err != nil {
+		errs = append(errs, errors.Wrap(err, "Cannot get standby status"))
+	}
+
 	i.logger.Info("Waiting for counters information")
 	c2, err := waitForCounters(ch)
 	if err != nil {
@@ -216,6 +495,28 @@ func (i *PGInfo) CollectGlobalInfo(db models.XODB) []error {
 		i.calcCountersDiff(i.Counters)
 	}
 
+	i.logger.Info("Waiting for wait events samples")
+	if i.WaitEventsProfile, err = waitForWaitEvents(waitEventsCh); err != nil {
+		errs = append(errs, errors.Wrap(err, "Cannot sample wait events"))
+	}
+
+	if statementsInstalled {
+		i.logger.Info("Collecting pg_stat_statements")
+		statements, err := i.getStatStatements(statementsDB)
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, "Cannot get pg_stat_statements"))
+		} else {
+			isDelta := statements1 != nil
+			if isDelta {
+				statements = statementsDiff(statements1, statements)
+			}
+			i.TopStatements = newTopStatements(statements, i.topCount, isDelta)
+		}
+	} else {
+		i.logger.Infof("pg_stat_statements is not installed in the %s database. Skipping statements collection",
+			i.StatementsDatabase)
+	}
+
 	i.logger.Info("Collecting processes command line information")
 	if err := i.collectProcesses(); err != nil {
 		errs = append(errs, errors.Wrap(err, "Cannot collect processes information"))
@@ -235,6 +536,95 @@ func (i *PGInfo) SetLogLevel(level logrus.Level) {
 	i.logger.SetLevel(level)
 }
 
+// SetHost sets the host used to connect to the server. Empty or a Unix socket directory
+// if the connection is local.
+func (i *PGInfo) SetHost(host string) {
+	i.host = host
+}
+
+// isLocalConnection returns true if the server runs on this host, so its files can be read
+func (i *PGInfo) isLocalConnection() bool {
+	switch i.host {
+	case "", "localhost", "127.0.0.1", "::1":
+		return true
+	}
+	return strings.HasPrefix(i.host, "/")
+}
+
+// SetTopCount sets the number of rows to show in the top N sections
+func (i *PGInfo) SetTopCount(count int) {
+	i.topCount = count
+}
+
+// SetStatementsDeltas enables reporting pg_stat_statements counters as the difference between
+// the beginning and the end of the sleep window instead of the values since the last stats reset
+func (i *PGInfo) SetStatementsDeltas(enabled bool) {
+	i.statementsDeltas = enabled
+}
+
+// SetIdleInTransactionThreshold sets the number of seconds a session must be idle in transaction
+// to be reported
+func (i *PGInfo) SetIdleInTransactionThreshold(seconds int) {
+	i.idleInTransactionThreshold = seconds
+}
+
+// SetWaitEventsInterval sets the time between wait events samples during the sleep window
+func (i *PGInfo) SetWaitEventsInterval(interval time.Duration) {
+	i.waitEventsInterval = interval
+}
+
+// SetWraparoundThresholds sets the percentages of the wraparound hard limit used to flag the
+// transaction ID and MultiXact ID ages as WARNING or CRITICAL
+func (i *PGInfo) SetWraparoundThresholds(warning, critical float64) {
+	i.wraparoundWarning = warning
+	i.wraparoundCritical = critical
+}
+
+// SetExactBloat enables the use of pgstattuple_approx, if the pgstattuple extension is installed,
+// to get the exact bloat of the largest tables
+func (i *PGInfo) SetExactBloat(enabled bool) {
+	i.exactBloat = enabled
+}
+
+// SetListEncryptedTables enables the list of the tables and indexes encrypted by pg_tde
+func (i *PGInfo) SetListEncryptedTables(enabled bool) {
+	i.listEncryptedTables = enabled
+}
+
+// FindStatementsDatabase returns the first database having pg_stat_statements installed or an
+// empty string if there is none. It must be called after SnapshotPerDatabaseInfo.
+func (i *PGInfo) FindStatementsDatabase() string {
+	for _, dbName := range i.databases {
+		for _, ext := range i.Extensions[dbName] {
+			if ext.Extname == "pg_stat_statements" {
+				return dbName
+			}
+		}
+	}
+	return ""
+}
+
+// SetStatementsConnection sets the connection used to read pg_stat_statements and its database.
+// The extension is often created only in the application databases.
+func (i *PGInfo) SetStatementsConnection(db models.XODB, dbName string) {
+	i.statementsDB = db
+	i.StatementsDatabase = dbName
+}
+
+// SetAllSettings enables the report of all the settings, not only the ones different from
+// their boot value
+func (i *PGInfo) SetAllSettings(enabled bool) {
+	i.allSettings = enabled
+}
+
+func (i *PGInfo) isExtensionInstalled(db models.XODB, name string) (bool, error) {
+	ext, err := models.GetExtensionInstalled(db, name)
+	if err != nil {
+		return false, err
+	}
+	return ext.Installed, nil
+}
+
 func getCounters(db models.XODB, ch chan interface{}) {
 	counters, err := models.GetCounters(db)
 	if err != nil {
@@ -0,0 +1,80 @@
+package pginfo
+
+import (
+	"regexp"
+
+	"github.com/Percona-Lab/pt-pg-summary/models"
+	"github.com/hashicorp/go-version"
+)
+
+// StandbyStatus has the recovery status of a standby server
+type StandbyStatus struct {
+	Recovery        *models.RecoveryStatus
+	WalReceivers    []*models.WalReceiver // Empty if the standby is not streaming
+	PrimaryConninfo string                // Password redacted
+	PrimarySlotName string
+	Conflicts       []*models.DatabaseConflicts
+}
+
+var (
+	conninfoPasswordRe = regexp.MustCompile(`(password\s*=\s*)('(\\.|[^'])*'|\S+)`)
+	conninfoURIRe      = regexp.MustCompile(`(postgres(ql)?://[^:@/]+:)[^@/]*@`)
+)
+
+// redactConninfo hides the password of a libpq connection string, in both keyword/value
+// and URI formats.
+func redactConninfo(conninfo string) string {
+	conninfo = conninfoPasswordRe.ReplaceAllString(conninfo, "${1}********")
+	return conninfoURIRe.ReplaceAllString(conninfo, "${1}********@")
+}
+
+// collectStandbyStatus returns nil if the server is not in recovery.
+func (i *PGInfo) collectStandbyStatus(db models.XODB) (*StandbyStatus, error) {
+	version10, _ := version.NewVersion("10.0.0")
+	version13, _ := version.NewVersion("13.0.0")
+
+	var recovery *models.RecoveryStatus
+	var err error
+	if i.ServerVersion.LessThan(version10) {
+		recovery, err = models.GetRecoveryStatus(db, "pg_last_xlog_receive_location", "pg_last_xlog_replay_location",
+			"pg_xlog_location_diff", "pg_is_xlog_replay_paused")
+	} else {
+		recovery, err = models.GetRecoveryStatus(db, "pg_last_wal_receive_lsn", "pg_last_wal_replay_lsn",
+			"pg_wal_lsn_diff", "pg_is_wal_replay_paused")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !recovery.IsInRecovery {
+		return nil, nil
+	}
+
+	status := &StandbyStatus{Recovery: recovery}
+
+	flushedLsn := "flushed_lsn"
+	if i.ServerVersion.LessThan(version13) {
+		flushedLsn = "received_lsn"
+	}
+	if status.WalReceivers, err = models.GetWalReceivers(db, flushedLsn); err != nil {
+		return nil, err
+	}
+	for _, wr := range status.WalReceivers {
+		wr.Conninfo = redactConninfo(wr.Conninfo)
+	}
+
+	// Before PostgreSQL 12 these are recovery.conf parameters, not available in pg_settings
+	for _, setting := range i.Settings {
+		switch setting.Name {
+		case "primary_conninfo":
+			status.PrimaryConninfo = redactConninfo(setting.Setting)
+		case "primary_slot_name":
+			status.PrimarySlotName = setting.Setting
+		}
+	}
+
+	if status.Conflicts, err = models.GetDatabaseConflicts(db); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
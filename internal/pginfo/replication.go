@@ -0,0 +1,25 @@
+package pginfo
+
+import (
+	"github.com/Percona-Lab/pt-pg-summary/models"
+	"github.com/hashicorp/go-version"
+)
+
+func (i *PGInfo) getReplicationSlots(db models.XODB) ([]*models.ReplicationSlots, error) {
+	version10, _ := version.NewVersion("10.0.0")
+	version13, _ := version.NewVersion("13.0.0")
+
+	if i.ServerVersion.LessThan(version10) {
+		return models.GetReplicationSlots(db, "pg_xlog_location_diff",
+			"CASE WHEN pg_is_in_recovery() THEN pg_last_xlog_receive_location() ELSE pg_current_xlog_location() END",
+			"NULL::text", "NULL::bigint")
+	}
+
+	walStatus, safeWalSize := "NULL::text", "NULL::bigint"
+	if !i.ServerVersion.LessThan(version13) {
+		walStatus, safeWalSize = "wal_status", "safe_wal_size"
+	}
+	return models.GetReplicationSlots(db, "pg_wal_lsn_diff",
+		"CASE WHEN pg_is_in_recovery() THEN pg_last_wal_receive_lsn() ELSE pg_current_wal_lsn() END",
+		walStatus, safeWalSize)
+}
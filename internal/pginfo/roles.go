@@ -0,0 +1,77 @@
+package pginfo
+
+import (
+	"github.com/Percona-Lab/pt-pg-summary/models"
+	"github.com/lib/pq"
+)
+
+// insufficient_privilege SQLSTATE
+const insufficientPrivilege = "42501"
+
+// Role has the attributes of a role and the type of its password hash
+type Role struct {
+	*models.Roles
+	PasswordType string // Empty if pg_authid is not readable
+}
+
+// RolesAudit has the roles and the findings of the security audit
+type RolesAudit struct {
+	Roles              []*Role
+	Memberships        []*models.RoleMemberships // One row per role and role it is a member of
+	PasswordHashes     bool                      // false if pg_authid is not readable
+	PasswordEncryption string
+	Superusers         []string
+	NoPasswordExpiry   []string // Login roles with a password and without an expiration date
+	MD5Passwords       []string // Roles using MD5 hashes when password_encryption is scram-sha-256
+}
+
+func (i *PGInfo) collectRolesAudit(db models.XODB) (*RolesAudit, error) {
+	roles, err := models.GetRoles(db)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := models.GetRoleMemberships(db)
+	if err != nil {
+		return nil, err
+	}
+
+	audit := &RolesAudit{Memberships: memberships, PasswordHashes: true}
+	passwordTypes := make(map[string]string)
+	hashes, err := models.GetPasswordHashes(db)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); !ok || pqErr.Code != insufficientPrivilege {
+			return nil, err
+		}
+		i.logger.Warn("pg_authid is not readable. Password hashes will not be checked")
+		audit.PasswordHashes = false
+	}
+	for _, h := range hashes {
+		passwordTypes[h.Rolname] = h.PasswordType
+	}
+
+	for _, setting := range i.Settings {
+		if setting.Name == "password_encryption" {
+			audit.PasswordEncryption = setting.Setting
+		}
+	}
+
+	for _, r := range roles {
+		role := &Role{Roles: r, PasswordType: passwordTypes[r.Rolname]}
+		audit.Roles = append(audit.Roles, role)
+
+		if r.Rolsuper {
+			audit.Superusers = append(audit.Superusers, r.Rolname)
+		}
+		// Without access to pg_authid we don't know if the role has a password
+		hasPassword := !audit.PasswordHashes || (role.PasswordType != "" && role.PasswordType != "none")
+		if r.Rolcanlogin && hasPassword && !r.Rolvaliduntil.Valid {
+			audit.NoPasswordExpiry = append(audit.NoPasswordExpiry, r.Rolname)
+		}
+		if audit.PasswordEncryption == "scram-sha-256" && role.PasswordType == "md5" {
+			audit.MD5Passwords = append(audit.MD5Passwords, r.Rolname)
+		}
+	}
+
+	return audit, nil
+}
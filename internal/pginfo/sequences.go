@@ -0,0 +1,193 @@
+package pginfo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Percona-Lab/pt-pg-summary/models"
+	"github.com/hashicorp/go-version"
+	"github.com/lib/pq"
+)
+
+// Limits of the integer types a sequence can be used for. They apply on every version when the
+// sequence range is wider than the column type, like a serial column altered to a smaller type or
+// a sequence created with the default bigint range for an integer column.
+var columnTypeLimits = map[string][2]int64{
+	"smallint": {math.MinInt16, math.MaxInt16},
+	"integer":  {math.MinInt32, math.MaxInt32},
+}
+
+// SequenceUsage has the usage of a sequence relative to its own limit or the limit of the
+// type of the column owning it, whichever is reached first.
+type SequenceUsage struct {
+	Schemaname   string
+	Sequencename string
+	OwnedBy      string // table.column, empty if the sequence is not owned by a column
+	ColumnType   string
+	LastValue    int64
+	Limit        int64
+	UsedRatio    float64
+	Growth       float64 // values per second during the sleep window
+	TimeLeft     string  // estimated time to exhaustion
+
+	called bool // false if nextval has never been called, LastValue is 0 then
+}
+
+type sequencesSnapshot struct {
+	taken  time.Time
+	values map[string]int64 // last values of the sequences already called
+}
+
+func (i *PGInfo) getSequences(db models.XODB) ([]*SequenceUsage, error) {
+	version10, _ := version.NewVersion("10.0.0")
+	sequences := []*SequenceUsage{}
+
+	if !i.ServerVersion.LessThan(version10) {
+		seqs, err := models.GetSequences(db)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range seqs {
+			su := &SequenceUsage{
+				Schemaname:   s.Schemaname,
+				Sequencename: s.Sequencename,
+				OwnedBy:      s.OwnedBy,
+				ColumnType:   s.ColumnType,
+				TimeLeft:     "unknown",
+			}
+			su.setUsage(s.LastValue.Int64, s.LastValue.Valid, s.MinValue, s.MaxValue, s.IncrementBy)
+			sequences = append(sequences, su)
+		}
+		return sequences, nil
+	}
+
+	seqs, err := models.GetSequences96(db)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range seqs {
+		name := pq.QuoteIdentifier(s.Schemaname) + "." + pq.QuoteIdentifier(s.Sequencename)
+		values, err := models.GetSequenceValues96(db, name)
+		if err != nil {
+			return nil, err
+		}
+		su := &SequenceUsage{
+			Schemaname:   s.Schemaname,
+			Sequencename: s.Sequencename,
+			OwnedBy:      s.OwnedBy,
+			ColumnType:   s.ColumnType,
+			TimeLeft:     "unknown",
+		}
+		su.setUsage(values.LastValue, values.IsCalled, values.MinValue, values.MaxValue, values.IncrementBy)
+		sequences = append(sequences, su)
+	}
+	return sequences, nil
+}
+
+// setUsage calculates the limit and the used ratio of a sequence. If the sequence has never
+// been called, the used ratio is 0.
+func (s *SequenceUsage) setUsage(lastValue int64, called bool, minValue, maxValue, incrementBy int64) {
+	typeLimits, hasTypeLimits := columnTypeLimits[s.ColumnType]
+
+	s.Limit = maxValue
+	if hasTypeLimits && typeLimits[1] < s.Limit {
+		s.Limit = typeLimits[1]
+	}
+	if incrementBy < 0 {
+		s.Limit = minValue
+		if hasTypeLimits && typeLimits[0] > s.Limit {
+			s.Limit = typeLimits[0]
+		}
+	}
+
+	if !called {
+		return
+	}
+	s.called = true
+	s.LastValue = lastValue
+	if (s.Limit > 0 && lastValue > 0) || (s.Limit < 0 && lastValue < 0) {
+		s.UsedRatio = float64(lastValue) / float64(s.Limit)
+	}
+}
+
+// setGrowth calculates the growth rate and the estimated time to exhaustion using the last
+// value of the sequence at the beginning of the sleep window.
+func (s *SequenceUsage) setGrowth(prevValue int64, seconds float64) {
+	if seconds <= 0 {
+		return
+	}
+	s.Growth = math.Abs(float64(s.LastValue-prevValue)) / seconds
+	if s.Growth == 0 {
+		s.TimeLeft = "no growth"
+		return
+	}
+	remaining := math.Abs(float64(s.Limit) - float64(s.LastValue))
+	s.TimeLeft = formatDuration(remaining / s.Growth)
+}
+
+// formatDuration returns a human readable representation of an amount of seconds, using
+// the 2 most significant units.
+func formatDuration(seconds float64) string {
+	if seconds > 100*365*86400 {
+		return "> 100 years"
+	}
+	d := int64(seconds)
+	days, hours, minutes := d/86400, d%86400/3600, d%3600/60
+	switch {
+	case days >= 365:
+		return fmt.Sprintf("%dy %dd", days/365, days%365)
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm %ds", minutes, d%60)
+	}
+}
+
+func sequenceKey(s *SequenceUsage) string {
+	return s.Schemaname + "." + s.Sequencename
+}
+
+func (i *PGInfo) snapshotSequences(db models.XODB, dbName string) error {
+	sequences, err := i.getSequences(db)
+	if err != nil {
+		return err
+	}
+	snapshot := &sequencesSnapshot{taken: time.Now(), values: make(map[string]int64, len(sequences))}
+	for _, s := range sequences {
+		// The first call during the sleep window would count the start value as growth
+		if s.called {
+			snapshot.values[sequenceKey(s)] = s.LastValue
+		}
+	}
+	i.sequences[dbName] = snapshot
+	return nil
+}
+
+func (i *PGInfo) collectSequences(db models.XODB, dbName string) error {
+	sequences, err := i.getSequences(db)
+	if err != nil {
+		return err
+	}
+
+	if s1, ok := i.sequences[dbName]; ok {
+		seconds := time.Since(s1.taken).Seconds()
+		for _, s := range sequences {
+			if prev, ok := s1.values[sequenceKey(s)]; ok {
+				s.setGrowth(prev, seconds)
+			}
+		}
+	}
+
+	sort.SliceStable(sequences, func(i, j int) bool {
+		return sequences[i].UsedRatio > sequences[j].UsedRatio
+	})
+	if len(sequences) > i.topCount {
+		sequences = sequences[:i.topCount]
+	}
+	i.Sequences[dbName] = sequences
+	return nil
+}
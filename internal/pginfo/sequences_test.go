@@ -0,0 +1,52 @@
+package pginfo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSequenceUsage(t *testing.T) {
+	s := &SequenceUsage{ColumnType: "integer"}
+	s.setUsage(math.MaxInt32/2, true, 1, math.MaxInt64, 1)
+	if s.Limit != math.MaxInt32 {
+		t.Errorf("Invalid limit for an integer column. Want %d, got %d", math.MaxInt32, s.Limit)
+	}
+	if s.UsedRatio < 0.49 || s.UsedRatio > 0.51 {
+		t.Errorf("Invalid used ratio. Want 0.5, got %f", s.UsedRatio)
+	}
+
+	s.setGrowth(s.LastValue-3600, 3600)
+	if s.Growth != 1 {
+		t.Errorf("Invalid growth. Want 1, got %f", s.Growth)
+	}
+	if s.TimeLeft != "34y 17d" {
+		t.Errorf("Invalid time left. Want 34y 17d, got %s", s.TimeLeft)
+	}
+
+	s = &SequenceUsage{ColumnType: "bigint"}
+	s.setUsage(-100, true, -1000, -1, -1)
+	if s.Limit != -1000 || s.UsedRatio != 0.1 {
+		t.Errorf("Invalid usage for a descending sequence: %+v", s)
+	}
+
+	s = &SequenceUsage{ColumnType: "smallint"}
+	s.setUsage(1, false, 1, math.MaxInt64, 1)
+	if s.Limit != math.MaxInt16 || s.UsedRatio != 0 || s.LastValue != 0 || s.called {
+		t.Errorf("Invalid usage for a sequence never called: %+v", s)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[float64]string{
+		59:                "0m 59s",
+		3700:              "1h 1m",
+		90000:             "1d 1h",
+		400 * 86400:       "1y 35d",
+		200 * 365 * 86400: "> 100 years",
+	}
+	for seconds, want := range tests {
+		if got := formatDuration(seconds); got != want {
+			t.Errorf("Invalid duration for %f seconds. Want %q, got %q", seconds, want, got)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package pginfo
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/Percona-Lab/pt-pg-summary/models"
+)
+
+// SettingValue is a setting with its values formatted using human readable units
+type SettingValue struct {
+	*models.Setting
+	Value      string
+	BootValue  string
+	ResetValue string // Value used by RESET, the one from the configuration without session changes
+	Origin     string // Source of the current value and file:line if it comes from a configuration file
+}
+
+// SettingsCategory has the settings of a pg_settings category
+type SettingsCategory struct {
+	Name     string
+	Settings []*SettingValue
+}
+
+// SettingsReport has the settings to report grouped by category
+type SettingsReport struct {
+	All            bool // false if only the settings different from their boot value are reported
+	Categories     []*SettingsCategory
+	PendingRestart []string // Settings changed in the configuration files, waiting for a restart
+}
+
+type settingUnit struct {
+	name string
+	size int64
+}
+
+var (
+	// Units used by SHOW, from the biggest to the smallest
+	memoryUnits = []settingUnit{{"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"kB", 1 << 10}, {"B", 1}}
+	timeUnits   = []settingUnit{{"d", 86400000}, {"h", 3600000}, {"min", 60000}, {"s", 1000}, {"ms", 1}}
+	// pg_settings units can have a multiplier, like 8kB or 16MB
+	settingUnitRe = regexp.MustCompile(`^(\d*)([a-zA-Z]+)$`)
+)
+
+// newSettingsReport groups the settings by category. pg_settings is already sorted by category
+// and name. Settings changed by the client or the session, like DateStyle set by lib/pq, are
+// compared using their reset value, the one they have without the session changes.
+func newSettingsReport(settings []*models.Setting, all bool) *SettingsReport {
+	report := &SettingsReport{All: all}
+	var category *SettingsCategory
+	for _, s := range settings {
+		if s.PendingRestart {
+			report.PendingRestart = append(report.PendingRestart, s.Name)
+		}
+		isDefault := s.Setting == s.BootVal
+		if s.Source == "client" || s.Source == "session" {
+			isDefault = s.ResetVal == s.BootVal
+		}
+		if !all && isDefault && !s.PendingRestart {
+			continue
+		}
+
+		if category == nil || category.Name != s.Category {
+			category = &SettingsCategory{Name: s.Category}
+			report.Categories = append(report.Categories, category)
+		}
+		origin := s.Source
+		if s.Sourcefile != "" {
+			origin = fmt.Sprintf("%s:%d", s.Sourcefile, s.Sourceline)
+		}
+		category.Settings = append(category.Settings, &SettingValue{
+			Setting:    s,
+			Value:      formatSettingValue(s.Setting, s.Unit, s.Vartype),
+			BootValue:  formatSettingValue(s.BootVal, s.Unit, s.Vartype),
+			ResetValue: formatSettingValue(s.ResetVal, s.Unit, s.Vartype),
+			Origin:     origin,
+		})
+	}
+	return report
+}
+
+// formatSettingValue returns the value using the biggest unit that represents it exactly, like SHOW.
+// Values not greater than zero have a special meaning (disabled, default) and are not converted.
+func formatSettingValue(value, unit, vartype string) string {
+	m := settingUnitRe.FindStringSubmatch(unit)
+	if m == nil || (vartype != "integer" && vartype != "real") {
+		return value
+	}
+	if vartype == "real" {
+		return value + unit
+	}
+
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || v <= 0 {
+		return value
+	}
+	multiplier := int64(1)
+	if m[1] != "" {
+		multiplier, _ = strconv.ParseInt(m[1], 10, 64)
+	}
+
+	for _, units := range [][]settingUnit{memoryUnits, timeUnits} {
+		for _, base := range units {
+			if base.name != m[2] {
+				continue
+			}
+			total := v * multiplier * base.size
+			for _, u := range units {
+				if total%u.size == 0 {
+					return fmt.Sprintf("%d%s", total/u.size, u.name)
+				}
+			}
+		}
+	}
+	return value + unit
+}
@@ -0,0 +1,65 @@
+package pginfo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Percona-Lab/pt-pg-summary/models"
+)
+
+func TestFormatSettingValue(t *testing.T) {
+	tests := []struct {
+		value, unit, vartype, want string
+	}{
+		{"1048576", "8kB", "integer", "8GB"},
+		{"1000", "8kB", "integer", "8000kB"},
+		{"65536", "kB", "integer", "64MB"},
+		{"64", "16MB", "integer", "1GB"},
+		{"60000", "ms", "integer", "1min"},
+		{"1500", "ms", "integer", "1500ms"},
+		{"86400", "s", "integer", "1d"},
+		{"-1", "ms", "integer", "-1"},
+		{"0", "kB", "integer", "0"},
+		{"2", "ms", "real", "2ms"},
+		{"on", "", "bool", "on"},
+		{"100", "", "integer", "100"},
+	}
+	for _, test := range tests {
+		if got := formatSettingValue(test.value, test.unit, test.vartype); got != test.want {
+			t.Errorf("Invalid value for %s %s. Want %q, got %q", test.value, test.unit, test.want, got)
+		}
+	}
+}
+
+func TestNewSettingsReport(t *testing.T) {
+	settings := []*models.Setting{
+		{Name: "autovacuum", Setting: "on", BootVal: "on", Category: "Autovacuum", Source: "default"},
+		{Name: "application_name", Setting: "pt-pg-summary", BootVal: "", Category: "Reporting and Logging / What to Log", Source: "client"},
+		{Name: "DateStyle", Setting: "ISO, MDY", BootVal: "ISO, MDY", ResetVal: "ISO, DMY",
+			Category: "Client Connection Defaults / Locale and Formatting", Source: "client"},
+		{Name: "shared_buffers", Setting: "16384", BootVal: "1024", ResetVal: "16384", Unit: "8kB", Vartype: "integer",
+			Category: "Resource Usage / Memory", Source: "configuration file", Sourcefile: "/etc/postgresql.conf", Sourceline: 12},
+		{Name: "work_mem", Setting: "4096", BootVal: "4096", Unit: "kB", Vartype: "integer",
+			Category: "Resource Usage / Memory", Source: "default", PendingRestart: true},
+	}
+
+	report := newSettingsReport(settings, false)
+	if !reflect.DeepEqual(report.PendingRestart, []string{"work_mem"}) {
+		t.Errorf("Invalid pending restart settings: %v", report.PendingRestart)
+	}
+	if len(report.Categories) != 2 || len(report.Categories[1].Settings) != 2 {
+		t.Fatalf("Only DateStyle and the Resource Usage / Memory settings should be reported: %+v", report.Categories)
+	}
+	if ds := report.Categories[0].Settings[0]; ds.Name != "DateStyle" || ds.ResetValue != "ISO, DMY" {
+		t.Errorf("DateStyle set in the configuration must be reported: %+v", ds)
+	}
+	sb := report.Categories[1].Settings[0]
+	if sb.Value != "128MB" || sb.BootValue != "8MB" || sb.ResetValue != "128MB" || sb.Origin != "/etc/postgresql.conf:12" {
+		t.Errorf("Invalid shared_buffers: %+v", sb)
+	}
+
+	report = newSettingsReport(settings, true)
+	if len(report.Categories) != 4 {
+		t.Errorf("Invalid number of categories. Want 4, got %d", len(report.Categories))
+	}
+}
@@ -0,0 +1,98 @@
+package pginfo
+
+import (
+	"sort"
+
+	"github.com/Percona-Lab/pt-pg-summary/models"
+	"github.com/hashicorp/go-version"
+)
+
+// TopStatements has the top N statements from pg_stat_statements sorted by different criteria.
+// If IsDelta is true, the values are the difference between the beginning and the end of the
+// sleep window instead of the values accumulated since the last stats reset.
+type TopStatements struct {
+	IsDelta          bool
+	ByTotalTime      []*models.StatStatements
+	ByMeanTime       []*models.StatStatements
+	ByCalls          []*models.StatStatements
+	ByRows           []*models.StatStatements
+	BySharedBlksRead []*models.StatStatements
+	ByTempBlks       []*models.StatStatements
+}
+
+type statementKey struct {
+	userid  int64
+	dbid    int64
+	queryid int64
+}
+
+func (i *PGInfo) getStatStatements(db models.XODB) ([]*models.StatStatements, error) {
+	version13, _ := version.NewVersion("13.0.0")
+	if i.ServerVersion.LessThan(version13) {
+		return models.GetStatStatements(db, "total_time", "mean_time", queryTextLength)
+	}
+	return models.GetStatStatements(db, "total_exec_time", "mean_exec_time", queryTextLength)
+}
+
+// statementsDiff returns the statements executed between the 2 snapshots, with the
+// difference of the counters. Statements not present in the 1st snapshot are returned as is.
+func statementsDiff(s1, s2 []*models.StatStatements) []*models.StatStatements {
+	prev := make(map[statementKey]*models.StatStatements, len(s1))
+	for _, s := range s1 {
+		prev[statementKey{s.Userid, s.Dbid, s.Queryid.Int64}] = s
+	}
+
+	diff := make([]*models.StatStatements, 0, len(s2))
+	for _, s := range s2 {
+		d := *s
+		if p, ok := prev[statementKey{s.Userid, s.Dbid, s.Queryid.Int64}]; ok && p.Calls <= s.Calls {
+			d.Calls -= p.Calls
+			d.TotalTime -= p.TotalTime
+			d.Rows -= p.Rows
+			d.SharedBlksHit -= p.SharedBlksHit
+			d.SharedBlksRead -= p.SharedBlksRead
+			d.TempBlksRead -= p.TempBlksRead
+			d.TempBlksWritten -= p.TempBlksWritten
+		}
+		if d.Calls == 0 {
+			continue
+		}
+		d.MeanTime = d.TotalTime / float64(d.Calls)
+		diff = append(diff, &d)
+	}
+	return diff
+}
+
+func newTopStatements(statements []*models.StatStatements, count int, isDelta bool) *TopStatements {
+	top := func(less func(a, b *models.StatStatements) bool) []*models.StatStatements {
+		s := make([]*models.StatStatements, len(statements))
+		copy(s, statements)
+		sort.SliceStable(s, func(i, j int) bool { return less(s[i], s[j]) })
+		if len(s) > count {
+			s = s[:count]
+		}
+		return s
+	}
+
+	return &TopStatements{
+		IsDelta: isDelta,
+		ByTotalTime: top(func(a, b *models.StatStatements) bool {
+			return a.TotalTime > b.TotalTime
+		}),
+		ByMeanTime: top(func(a, b *models.StatStatements) bool {
+			return a.MeanTime > b.MeanTime
+		}),
+		ByCalls: top(func(a, b *models.StatStatements) bool {
+			return a.Calls > b.Calls
+		}),
+		ByRows: top(func(a, b *models.StatStatements) bool {
+			return a.Rows > b.Rows
+		}),
+		BySharedBlksRead: top(func(a, b *models.StatStatements) bool {
+			return a.SharedBlksRead > b.SharedBlksRead
+		}),
+		ByTempBlks: top(func(a, b *models.StatStatements) bool {
+			return a.TempBlksRead+a.TempBlksWritten > b.TempBlksRead+b.TempBlksWritten
+		}),
+	}
+}
@@ -0,0 +1,34 @@
+package pginfo
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/Percona-Lab/pt-pg-summary/models"
+)
+
+func TestStatementsDiff(t *testing.T) {
+	s1 := []*models.StatStatements{
+		{Userid: 10, Dbid: 1, Queryid: sql.NullInt64{Int64: 1, Valid: true}, Calls: 10, TotalTime: 100, Rows: 10},
+		{Userid: 10, Dbid: 1, Queryid: sql.NullInt64{Int64: 2, Valid: true}, Calls: 5, TotalTime: 50, Rows: 5},
+	}
+	s2 := []*models.StatStatements{
+		{Userid: 10, Dbid: 1, Queryid: sql.NullInt64{Int64: 1, Valid: true}, Calls: 14, TotalTime: 180, Rows: 18},
+		{Userid: 10, Dbid: 1, Queryid: sql.NullInt64{Int64: 2, Valid: true}, Calls: 5, TotalTime: 50, Rows: 5},
+		{Userid: 10, Dbid: 2, Queryid: sql.NullInt64{Int64: 1, Valid: true}, Calls: 2, TotalTime: 6, Rows: 2},
+	}
+
+	diff := statementsDiff(s1, s2)
+	if len(diff) != 2 {
+		t.Fatalf("Invalid diff length. Want 2, got %d", len(diff))
+	}
+	if diff[0].Calls != 4 || diff[0].TotalTime != 80 || diff[0].MeanTime != 20 || diff[0].Rows != 8 {
+		t.Errorf("Invalid diff for an existing statement: %+v", diff[0])
+	}
+	if diff[1].Dbid != 2 || diff[1].Calls != 2 || diff[1].MeanTime != 3 {
+		t.Errorf("Invalid diff for a new statement: %+v", diff[1])
+	}
+	if s2[0].Calls != 14 {
+		t.Errorf("The 2nd snapshot must not be modified")
+	}
+}
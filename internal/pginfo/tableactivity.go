@@ -0,0 +1,128 @@
+package pginfo
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Percona-Lab/pt-pg-summary/models"
+)
+
+// TableRates has the pg_stat_user_tables counters of a table as rates per second
+type TableRates struct {
+	Schemaname  string
+	Relname     string
+	SeqScan     float64
+	SeqTupRead  float64
+	IdxScan     float64
+	IdxTupFetch float64
+	Inserts     float64
+	Updates     float64
+	HotUpdates  float64
+	Deletes     float64
+	HotRatio    float64 // HOT updates / updates
+}
+
+// TableActivity has the busiest tables of a database between 2 snapshots of pg_stat_user_tables
+type TableActivity struct {
+	Seconds   float64
+	BySeqScan []*TableRates
+	ByIdxScan []*TableRates
+	ByInserts []*TableRates
+	ByUpdates []*TableRates
+	ByDeletes []*TableRates
+}
+
+type tableActivitySnapshot struct {
+	taken  time.Time
+	tables []*models.TableActivity
+}
+
+func (i *PGInfo) snapshotTableActivity(db models.XODB, dbName string) error {
+	tables, err := models.GetTableActivity(db)
+	if err != nil {
+		return err
+	}
+	i.tableActivity[dbName] = &tableActivitySnapshot{taken: time.Now(), tables: tables}
+	return nil
+}
+
+func (i *PGInfo) collectTableActivity(db models.XODB, dbName string) error {
+	s1, ok := i.tableActivity[dbName]
+	if !ok {
+		i.logger.Warnf("There is no 1st snapshot of the table activity for the %s database", dbName)
+		return nil
+	}
+	tables, err := models.GetTableActivity(db)
+	if err != nil {
+		return err
+	}
+	seconds := time.Since(s1.taken).Seconds()
+	i.TableActivity[dbName] = newTableActivity(tableRates(s1.tables, tables, seconds), i.topCount, seconds)
+	return nil
+}
+
+// tableRates returns the rates per second of the tables having activity between the 2 snapshots.
+// Tables not present in the 1st snapshot or whose stats were reset are ignored.
+func tableRates(t1, t2 []*models.TableActivity, seconds float64) []*TableRates {
+	prev := make(map[int64]*models.TableActivity, len(t1))
+	for _, t := range t1 {
+		prev[t.Relid] = t
+	}
+
+	rates := make([]*TableRates, 0, len(t2))
+	if seconds <= 0 {
+		return rates
+	}
+	for _, t := range t2 {
+		p, ok := prev[t.Relid]
+		if !ok || t.SeqScan < p.SeqScan || t.IdxScan < p.IdxScan || t.NTupIns < p.NTupIns ||
+			t.NTupUpd < p.NTupUpd || t.NTupDel < p.NTupDel {
+			continue
+		}
+		r := &TableRates{
+			Schemaname:  t.Schemaname,
+			Relname:     t.Relname,
+			SeqScan:     float64(t.SeqScan-p.SeqScan) / seconds,
+			SeqTupRead:  float64(t.SeqTupRead-p.SeqTupRead) / seconds,
+			IdxScan:     float64(t.IdxScan-p.IdxScan) / seconds,
+			IdxTupFetch: float64(t.IdxTupFetch-p.IdxTupFetch) / seconds,
+			Inserts:     float64(t.NTupIns-p.NTupIns) / seconds,
+			Updates:     float64(t.NTupUpd-p.NTupUpd) / seconds,
+			HotUpdates:  float64(t.NTupHotUpd-p.NTupHotUpd) / seconds,
+			Deletes:     float64(t.NTupDel-p.NTupDel) / seconds,
+		}
+		if r.SeqScan+r.IdxScan+r.Inserts+r.Updates+r.Deletes == 0 {
+			continue
+		}
+		if r.Updates > 0 {
+			r.HotRatio = r.HotUpdates / r.Updates
+		}
+		rates = append(rates, r)
+	}
+	return rates
+}
+
+func newTableActivity(rates []*TableRates, count int, seconds float64) *TableActivity {
+	top := func(value func(r *TableRates) float64) []*TableRates {
+		s := make([]*TableRates, 0, len(rates))
+		for _, r := range rates {
+			if value(r) > 0 {
+				s = append(s, r)
+			}
+		}
+		sort.SliceStable(s, func(i, j int) bool { return value(s[i]) > value(s[j]) })
+		if len(s) > count {
+			s = s[:count]
+		}
+		return s
+	}
+
+	return &TableActivity{
+		Seconds:   seconds,
+		BySeqScan: top(func(r *TableRates) float64 { return r.SeqScan }),
+		ByIdxScan: top(func(r *TableRates) float64 { return r.IdxScan }),
+		ByInserts: top(func(r *TableRates) float64 { return r.Inserts }),
+		ByUpdates: top(func(r *TableRates) float64 { return r.Updates }),
+		ByDeletes: top(func(r *TableRates) float64 { return r.Deletes }),
+	}
+}
@@ -0,0 +1,35 @@
+package pginfo
+
+import (
+	"testing"
+
+	"github.com/Percona-Lab/pt-pg-summary/models"
+)
+
+func TestTableRates(t *testing.T) {
+	t1 := []*models.TableActivity{
+		{Relid: 1, Relname: "t1", SeqScan: 10, NTupIns: 100, NTupUpd: 10, NTupHotUpd: 5},
+		{Relid: 2, Relname: "t2", SeqScan: 10},
+		{Relid: 3, Relname: "t3", SeqScan: 10, IdxScan: 100},
+	}
+	t2 := []*models.TableActivity{
+		{Relid: 1, Relname: "t1", SeqScan: 30, NTupIns: 200, NTupUpd: 30, NTupHotUpd: 20},
+		{Relid: 2, Relname: "t2", SeqScan: 10},
+		{Relid: 3, Relname: "t3", SeqScan: 0, IdxScan: 0},
+		{Relid: 4, Relname: "t4", SeqScan: 50},
+	}
+
+	rates := tableRates(t1, t2, 10)
+	if len(rates) != 1 {
+		t.Fatalf("Invalid rates length. Want 1, got %d", len(rates))
+	}
+	r := rates[0]
+	if r.Relname != "t1" || r.SeqScan != 2 || r.Inserts != 10 || r.Updates != 2 || r.HotRatio != 0.75 {
+		t.Errorf("Invalid rates: %+v", r)
+	}
+
+	activity := newTableActivity(rates, 10, 10)
+	if len(activity.BySeqScan) != 1 || len(activity.ByIdxScan) != 0 || len(activity.ByDeletes) != 0 {
+		t.Errorf("Tables without activity must not be listed: %+v", activity)
+	}
+}
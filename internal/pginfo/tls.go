@@ -0,0 +1,58 @@
+package pginfo
+
+import (
+	"github.com/Percona-Lab/pt-pg-summary/models"
+	"github.com/hashicorp/go-version"
+)
+
+// Server settings related to TLS, in report order
+var tlsSettings = []string{"ssl", "ssl_min_protocol_version", "ssl_max_protocol_version", "ssl_ciphers",
+	"ssl_cert_file", "ssl_key_file", "ssl_ca_file", "ssl_crl_file"}
+
+// ConnectionSecurity has the server TLS settings and the sessions encryption per user and client
+type ConnectionSecurity struct {
+	Settings  []*models.Setting
+	Clients   []*models.ConnectionSecurity
+	Encrypted int64 // Network sessions using TLS or GSSAPI encryption
+	Cleartext int64 // Network sessions without encryption
+	Local     int64 // Sessions through a Unix socket, their traffic never goes through the network
+	Unknown   int64 // Sessions of other users, their client is hidden without pg_read_all_stats
+}
+
+func (i *PGInfo) collectConnectionSecurity(db models.XODB) (*ConnectionSecurity, error) {
+	version12, _ := version.NewVersion("12.0.0")
+
+	gssEncrypted, gssJoin := "false", ""
+	if !i.ServerVersion.LessThan(version12) {
+		gssEncrypted, gssJoin = "COALESCE(g.encrypted, false)", "LEFT JOIN pg_stat_gssapi g ON g.pid = a.pid"
+	}
+	clients, err := models.GetConnectionSecurity(db, gssEncrypted, gssJoin)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := &ConnectionSecurity{Clients: clients}
+	for _, c := range clients {
+		switch {
+		case c.Local:
+			cs.Local += c.Count
+		case c.Hidden:
+			cs.Unknown += c.Count
+		case c.Ssl || c.GssEncrypted:
+			cs.Encrypted += c.Count
+		default:
+			cs.Cleartext += c.Count
+		}
+	}
+
+	// Not all the settings exist in all versions
+	for _, name := range tlsSettings {
+		for _, setting := range i.Settings {
+			if setting.Name == name {
+				cs.Settings = append(cs.Settings, setting)
+			}
+		}
+	}
+
+	return cs, nil
+}
@@ -0,0 +1,311 @@
+package pginfo
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Percona-Lab/pt-pg-summary/models"
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	defaultPort = 5432
+	// Maximum number of cascading levels to follow, in case of a replication loop we cannot detect
+	maxTopologyDepth = 10
+)
+
+// ConnectFunc opens a connection to a server of the replication topology
+type ConnectFunc func(host string, port int) (*sql.DB, error)
+
+// TopologyServer is a server of the replication topology. Except for the root server, the
+// replication fields are the ones reported by its upstream server in pg_stat_replication.
+type TopologyServer struct {
+	Host             string
+	Port             int
+	Role             string // primary, standby or unknown if we cannot connect to the server
+	Current          bool   // true for the server the report is about
+	Error            string
+	ApplicationName  string
+	State            string
+	SyncState        string
+	ReplayLag        int64
+	ReplayLagSeconds sql.NullFloat64
+	downstream       []*TopologyServer
+}
+
+// TopologyNode is a server in the replication topology tree. Prefix is used to print the tree.
+type TopologyNode struct {
+	Level  int
+	Prefix string
+	Server *TopologyServer
+}
+
+var (
+	conninfoHostRe = regexp.MustCompile(`(?:^|\s)host\s*=\s*'?([^'\s]+)`)
+	conninfoPortRe = regexp.MustCompile(`(?:^|\s)port\s*=\s*'?(\d+)`)
+)
+
+// CollectTopology discovers the replication topology starting from the current server. It goes up
+// to the primary following the WAL receivers upstream hosts and then follows the standbys in
+// pg_stat_replication. Standbys are expected to listen on the same port as the current server
+// since pg_stat_replication does not have it. Servers with another system identifier are not
+// part of this cluster and they are not followed.
+func (i *PGInfo) CollectTopology(db models.XODB, host string, port int, connect ConnectFunc) error {
+	if port == 0 {
+		port = defaultPort
+	}
+	currentID, err := serverIdentity(db)
+	if err != nil {
+		return err
+	}
+	rootHost, rootPort := host, port
+	rootIsCurrent := true
+	visited := map[string]bool{topologyKey(host, port): true}
+
+	upHost, upPort, err := upstreamServer(db)
+	if err != nil {
+		return err
+	}
+	for depth := 0; depth < maxTopologyDepth && upHost != ""; depth++ {
+		if visited[topologyKey(upHost, upPort)] {
+			break
+		}
+		visited[topologyKey(upHost, upPort)] = true
+		conn, err := connect(upHost, upPort)
+		if err != nil {
+			i.logger.Warnf("Cannot connect to the upstream server %s:%d: %s", upHost, upPort, err)
+			break
+		}
+		rootHost, rootPort, rootIsCurrent = upHost, upPort, false
+		upHost, upPort, err = upstreamServer(conn)
+		conn.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	root := &TopologyServer{Host: rootHost, Port: rootPort, Current: rootIsCurrent}
+	visited = make(map[string]bool)
+	i.followStandbys(root, db, currentID, port, connect, visited, 0)
+	i.Topology = topologyTree(root)
+	return nil
+}
+
+// followStandbys adds the standbys of the server to the topology. The current server is detected
+// by its identity since the addresses in pg_stat_replication and in the WAL receiver conninfo can
+// be different from the host used to connect to it.
+func (i *PGInfo) followStandbys(server *TopologyServer, db models.XODB, currentID *models.ServerIdentity, port int,
+	connect ConnectFunc, visited map[string]bool, depth int) {
+	visited[topologyKey(server.Host, server.Port)] = true
+	server.Role = "unknown"
+
+	conn := db
+	if !server.Current {
+		c, err := connect(server.Host, server.Port)
+		if err != nil {
+			server.Error = err.Error()
+			return
+		}
+		defer c.Close()
+		conn = c
+		id, err := serverIdentity(c)
+		if err != nil {
+			server.Error = err.Error()
+			return
+		}
+		// A logical subscriber or any other server listening on the standby address
+		if id.SystemIdentifier != currentID.SystemIdentifier {
+			server.Error = "not in this cluster"
+			return
+		}
+		if *id == *currentID {
+			server.Current = true
+			conn = db
+		}
+	}
+
+	standbys, isStandby, err := standbyServers(conn, port)
+	if err != nil {
+		server.Error = err.Error()
+		return
+	}
+	server.Role = "primary"
+	if isStandby {
+		server.Role = "standby"
+	}
+	server.downstream = standbys
+
+	for _, standby := range standbys {
+		switch {
+		case standby.Host == "":
+			standby.Role = "unknown"
+			standby.Error = "connected through a Unix socket"
+		case visited[topologyKey(standby.Host, standby.Port)]:
+			standby.Role = "unknown"
+			standby.Error = "already in the topology"
+		case depth+1 >= maxTopologyDepth:
+			standby.Role = "unknown"
+			standby.Error = "too many cascading levels"
+		default:
+			i.followStandbys(standby, db, currentID, port, connect, visited, depth+1)
+		}
+	}
+}
+
+// standbyServers returns the standbys connected to the server and if the server is a standby.
+// Logical replication and backup walsenders are skipped since they are not standbys.
+func standbyServers(db models.XODB, port int) ([]*TopologyServer, bool, error) {
+	serverVersion, err := models.GetServerVersion(db)
+	if err != nil {
+		return nil, false, err
+	}
+	v, err := parseServerVersion(serverVersion.Version)
+	if err != nil {
+		return nil, false, err
+	}
+	recovery, err := models.GetClusterInfos(db)
+	if err != nil {
+		return nil, false, err
+	}
+	isStandby := len(recovery) > 0 && recovery[0].IsSlave
+
+	standbys := []*TopologyServer{}
+	version10, _ := version.NewVersion("10.0.0")
+	if v.LessThan(version10) {
+		rows, err := models.GetSlaveHosts96s(db)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, r := range rows {
+			if r.WalsenderType != "physical" {
+				continue
+			}
+			standbys = append(standbys, &TopologyServer{Host: r.ClientAddr.String, Port: port,
+				ApplicationName: r.ApplicationName.String, State: r.State.String, SyncState: r.SyncState.String,
+				ReplayLag: r.ReplayLag.Int64, ReplayLagSeconds: r.ReplayLagSeconds})
+		}
+		return standbys, isStandby, nil
+	}
+
+	rows, err := models.GetSlaveHosts10s(db)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, r := range rows {
+		if r.WalsenderType != "physical" {
+			continue
+		}
+		standbys = append(standbys, &TopologyServer{Host: r.ClientAddr.String, Port: port,
+			ApplicationName: r.ApplicationName.String, State: r.State.String, SyncState: r.SyncState.String,
+			ReplayLag: r.ReplayLag.Int64, ReplayLagSeconds: r.ReplayLagSeconds})
+	}
+	return standbys, isStandby, nil
+}
+
+// serverIdentity returns the values identifying a running server. The system identifier is the
+// same for a primary and its standbys, so the start time and port identify the server itself.
+// Before PostgreSQL 9.6 the system identifier is not available and it is empty for all servers.
+func serverIdentity(db models.XODB) (*models.ServerIdentity, error) {
+	serverVersion, err := models.GetServerVersion(db)
+	if err != nil {
+		return nil, err
+	}
+	v, err := parseServerVersion(serverVersion.Version)
+	if err != nil {
+		return nil, err
+	}
+	version96, _ := version.NewVersion("9.6.0")
+	systemIdentifier := "''"
+	if !v.LessThan(version96) {
+		systemIdentifier = "(SELECT system_identifier FROM pg_control_system())::text"
+	}
+	return models.GetServerIdentity(db, systemIdentifier)
+}
+
+// upstreamServer returns the host and port the WAL receiver is connected to. The host is
+// empty if the server is not a standby streaming from another server.
+func upstreamServer(db models.XODB) (string, int, error) {
+	serverVersion, err := models.GetServerVersion(db)
+	if err != nil {
+		return "", 0, err
+	}
+	v, err := parseServerVersion(serverVersion.Version)
+	if err != nil {
+		return "", 0, err
+	}
+	version96, _ := version.NewVersion("9.6.0")
+	version13, _ := version.NewVersion("13.0.0")
+	if v.LessThan(version96) { // pg_stat_wal_receiver is not available
+		return "", 0, nil
+	}
+	flushedLsn := "flushed_lsn"
+	if v.LessThan(version13) {
+		flushedLsn = "received_lsn"
+	}
+	receivers, err := models.GetWalReceivers(db, flushedLsn)
+	if err != nil {
+		return "", 0, err
+	}
+	for _, r := range receivers {
+		if host, port := conninfoHostPort(r.Conninfo); host != "" {
+			return host, port, nil
+		}
+	}
+	return "", 0, nil
+}
+
+// conninfoHostPort returns the host and port of a libpq connection string, in both keyword/value
+// and URI formats. Only the first host is returned if there is a list of hosts.
+func conninfoHostPort(conninfo string) (string, int) {
+	host, port := "", defaultPort
+
+	if strings.HasPrefix(conninfo, "postgres://") || strings.HasPrefix(conninfo, "postgresql://") {
+		u, err := url.Parse(conninfo)
+		if err != nil {
+			return "", 0
+		}
+		host = u.Hostname()
+		if p, err := strconv.Atoi(u.Port()); err == nil {
+			port = p
+		}
+		return host, port
+	}
+
+	if m := conninfoHostRe.FindStringSubmatch(conninfo); m != nil {
+		host = strings.Split(m[1], ",")[0]
+	}
+	if m := conninfoPortRe.FindStringSubmatch(conninfo); m != nil {
+		port, _ = strconv.Atoi(m[1])
+	}
+	// A host starting with a slash is a Unix socket directory
+	if strings.HasPrefix(host, "/") {
+		host = ""
+	}
+	return host, port
+}
+
+func topologyKey(host string, port int) string {
+	return fmt.Sprintf("%s:%d", host, port)
+}
+
+// topologyTree returns the servers of the replication topology in print order
+func topologyTree(root *TopologyServer) []*TopologyNode {
+	nodes := []*TopologyNode{}
+	var walk func(server *TopologyServer, level int)
+	walk = func(server *TopologyServer, level int) {
+		prefix := ""
+		if level > 0 {
+			prefix = strings.Repeat("    ", level-1) + "`-> "
+		}
+		nodes = append(nodes, &TopologyNode{Level: level, Prefix: prefix, Server: server})
+		for _, standby := range server.downstream {
+			walk(standby, level+1)
+		}
+	}
+	walk(root, 0)
+	return nodes
+}
@@ -0,0 +1,141 @@
+package pginfo
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Percona-Lab/pt-pg-summary/models"
+	"github.com/hashicorp/go-version"
+)
+
+// WaitEventsProfile is the histogram of the wait events sampled during the sleep window.
+// Percent is the share of the sampled active time: the number of times a session was found
+// in that wait event over the total number of active sessions found in all the samples.
+type WaitEventsProfile struct {
+	Samples    int
+	Interval   time.Duration
+	ByEvent    []*WaitEventShare
+	ByDatabase []*WaitEventShare // by database, backend type and wait event
+}
+
+// WaitEventShare has the number of times a wait event was sampled and its share of the active time
+type WaitEventShare struct {
+	Datname       string
+	BackendType   string
+	WaitEventType string
+	WaitEvent     string
+	Count         int64
+	Percent       float64
+}
+
+// defaultWaitEventsInterval is the default time between wait events samples
+const defaultWaitEventsInterval = 100 * time.Millisecond
+
+type waitEventKey struct {
+	datname       string
+	backendType   string
+	waitEventType string
+	waitEvent     string
+}
+
+// sampleWaitEvents samples pg_stat_activity every interval until the duration is over and
+// sends the profile (or an error) to the channel.
+func (i *PGInfo) sampleWaitEvents(db models.XODB, duration, interval time.Duration, ch chan interface{}) {
+	version10, _ := version.NewVersion("10.0.0")
+	backendType := "backend_type"
+	if i.ServerVersion.LessThan(version10) {
+		backendType = "'client backend'"
+	}
+	if interval <= 0 {
+		interval = defaultWaitEventsInterval
+	}
+
+	samples := [][]*models.WaitEventSamples{}
+	deadline := time.Now().Add(duration)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		sample, err := models.GetWaitEventSamples(db, backendType, ApplicationName)
+		if err != nil {
+			ch <- err
+			return
+		}
+		samples = append(samples, sample)
+		if time.Now().Add(interval).After(deadline) {
+			break
+		}
+		<-ticker.C
+	}
+
+	profile := newWaitEventsProfile(samples)
+	profile.Interval = interval
+	ch <- profile
+}
+
+func waitForWaitEvents(ch chan interface{}) (*WaitEventsProfile, error) {
+	resp := <-ch
+	if err, ok := resp.(error); ok {
+		return nil, err
+	}
+
+	return resp.(*WaitEventsProfile), nil
+}
+
+func newWaitEventsProfile(samples [][]*models.WaitEventSamples) *WaitEventsProfile {
+	byEvent := make(map[waitEventKey]int64)
+	byDatabase := make(map[waitEventKey]int64)
+	var total int64
+
+	for _, sample := range samples {
+		for _, s := range sample {
+			byEvent[waitEventKey{waitEventType: s.WaitEventType, waitEvent: s.WaitEvent}] += s.Count
+			byDatabase[waitEventKey{s.Datname, s.BackendType, s.WaitEventType, s.WaitEvent}] += s.Count
+			total += s.Count
+		}
+	}
+
+	shares := func(counts map[waitEventKey]int64) []*WaitEventShare {
+		res := make([]*WaitEventShare, 0, len(counts))
+		for k, count := range counts {
+			res = append(res, &WaitEventShare{
+				Datname:       k.datname,
+				BackendType:   k.backendType,
+				WaitEventType: k.waitEventType,
+				WaitEvent:     k.waitEvent,
+				Count:         count,
+				Percent:       float64(count) * 100 / float64(total),
+			})
+		}
+		return res
+	}
+
+	profile := &WaitEventsProfile{
+		Samples:    len(samples),
+		ByEvent:    shares(byEvent),
+		ByDatabase: shares(byDatabase),
+	}
+
+	sort.Slice(profile.ByEvent, func(i, j int) bool {
+		a, b := profile.ByEvent[i], profile.ByEvent[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.WaitEventType+a.WaitEvent < b.WaitEventType+b.WaitEvent
+	})
+	sort.Slice(profile.ByDatabase, func(i, j int) bool {
+		a, b := profile.ByDatabase[i], profile.ByDatabase[j]
+		if a.Datname != b.Datname {
+			return a.Datname < b.Datname
+		}
+		if a.BackendType != b.BackendType {
+			return a.BackendType < b.BackendType
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.WaitEventType+a.WaitEvent < b.WaitEventType+b.WaitEvent
+	})
+
+	return profile
+}
@@ -0,0 +1,62 @@
+package pginfo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Percona-Lab/pt-pg-summary/models"
+)
+
+func TestNewWaitEventsProfile(t *testing.T) {
+	samples := [][]*models.WaitEventSamples{
+		{
+			{Datname: "shop", BackendType: "client backend", WaitEventType: "IO", WaitEvent: "DataFileRead", Count: 2},
+			{Datname: "shop", BackendType: "client backend", WaitEventType: "CPU", WaitEvent: "CPU", Count: 1},
+		},
+		{}, // no active sessions in this round
+		{
+			{Datname: "sales", BackendType: "client backend", WaitEventType: "IO", WaitEvent: "DataFileRead", Count: 1},
+			{Datname: "shop", BackendType: "client backend", WaitEventType: "Lock", WaitEvent: "transactionid", Count: 3},
+			{Datname: "", BackendType: "autovacuum worker", WaitEventType: "CPU", WaitEvent: "CPU", Count: 1},
+		},
+	}
+
+	profile := newWaitEventsProfile(samples)
+	if profile.Samples != 3 {
+		t.Errorf("Invalid number of samples. Want 3, got %d", profile.Samples)
+	}
+
+	wantByEvent := []*WaitEventShare{
+		{WaitEventType: "IO", WaitEvent: "DataFileRead", Count: 3, Percent: 37.5},
+		{WaitEventType: "Lock", WaitEvent: "transactionid", Count: 3, Percent: 37.5},
+		{WaitEventType: "CPU", WaitEvent: "CPU", Count: 2, Percent: 25},
+	}
+	if !reflect.DeepEqual(profile.ByEvent, wantByEvent) {
+		t.Errorf("Invalid profile by event.\nWant %+v\ngot  %+v", shareValues(wantByEvent), shareValues(profile.ByEvent))
+	}
+
+	wantByDatabase := []*WaitEventShare{
+		{"", "autovacuum worker", "CPU", "CPU", 1, 12.5},
+		{"sales", "client backend", "IO", "DataFileRead", 1, 12.5},
+		{"shop", "client backend", "Lock", "transactionid", 3, 37.5},
+		{"shop", "client backend", "IO", "DataFileRead", 2, 25},
+		{"shop", "client backend", "CPU", "CPU", 1, 12.5},
+	}
+	if !reflect.DeepEqual(profile.ByDatabase, wantByDatabase) {
+		t.Errorf("Invalid profile by database.\nWant %+v\ngot  %+v", shareValues(wantByDatabase),
+			shareValues(profile.ByDatabase))
+	}
+
+	profile = newWaitEventsProfile([][]*models.WaitEventSamples{{}, {}})
+	if profile.Samples != 2 || len(profile.ByEvent) != 0 || len(profile.ByDatabase) != 0 {
+		t.Errorf("Invalid profile without active sessions: %+v", profile)
+	}
+}
+
+func shareValues(shares []*WaitEventShare) []WaitEventShare {
+	res := make([]WaitEventShare, 0, len(shares))
+	for _, s := range shares {
+		res = append(res, *s)
+	}
+	return res
+}
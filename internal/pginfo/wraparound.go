@@ -0,0 +1,79 @@
+package pginfo
+
+import (
+	"github.com/Percona-Lab/pt-pg-summary/models"
+)
+
+// wraparoundLimit is the hard limit for the transaction ID and MultiXact ID ages
+const wraparoundLimit = 1 << 31
+
+// WraparoundRisk has the transaction ID and MultiXact ID ages as a percentage of the wraparound
+// hard limit. Level is WARNING or CRITICAL if any of them is past the configured percentages and
+// PastFreezeMaxAge is true if an anti-wraparound autovacuum is due.
+type WraparoundRisk struct {
+	XidPct           float64
+	MxidPct          float64
+	PastFreezeMaxAge bool
+	Level            string
+}
+
+// DatabaseWraparoundRisk has the wraparound risk of a database
+type DatabaseWraparoundRisk struct {
+	*models.DatabaseWraparound
+	WraparoundRisk
+}
+
+// TableWraparoundRisk has the wraparound risk of a table
+type TableWraparoundRisk struct {
+	*models.TableWraparound
+	WraparoundRisk
+}
+
+func (i *PGInfo) wraparoundRisk(xidAge, mxidAge, freezeMaxAge, multixactFreezeMaxAge int64) WraparoundRisk {
+	risk := WraparoundRisk{
+		XidPct:           float64(xidAge) * 100 / wraparoundLimit,
+		MxidPct:          float64(mxidAge) * 100 / wraparoundLimit,
+		PastFreezeMaxAge: xidAge > freezeMaxAge || mxidAge > multixactFreezeMaxAge,
+	}
+
+	switch {
+	case risk.XidPct >= i.wraparoundCritical || risk.MxidPct >= i.wraparoundCritical:
+		risk.Level = "CRITICAL"
+	case risk.XidPct >= i.wraparoundWarning || risk.MxidPct >= i.wraparoundWarning:
+		risk.Level = "WARNING"
+	}
+	return risk
+}
+
+func (i *PGInfo) collectDatabaseWraparound(db models.XODB) error {
+	databases, err := models.GetDatabaseWraparounds(db)
+	if err != nil {
+		return err
+	}
+
+	i.DatabaseWraparound = make([]*DatabaseWraparoundRisk, 0, len(databases))
+	for _, d := range databases {
+		i.DatabaseWraparound = append(i.DatabaseWraparound, &DatabaseWraparoundRisk{
+			DatabaseWraparound: d,
+			WraparoundRisk:     i.wraparoundRisk(d.XidAge, d.MxidAge, d.FreezeMaxAge, d.MultixactFreezeMaxAge),
+		})
+	}
+	return nil
+}
+
+func (i *PGInfo) collectTableWraparound(db models.XODB, dbName string) error {
+	tables, err := models.GetTableWraparounds(db, i.topCount)
+	if err != nil {
+		return err
+	}
+
+	risks := make([]*TableWraparoundRisk, 0, len(tables))
+	for _, t := range tables {
+		risks = append(risks, &TableWraparoundRisk{
+			TableWraparound: t,
+			WraparoundRisk:  i.wraparoundRisk(t.XidAge, t.MxidAge, t.FreezeMaxAge, t.MultixactFreezeMaxAge),
+		})
+	}
+	i.TableWraparound[dbName] = risks
+	return nil
+}
@@ -0,0 +1,50 @@
+package pginfo
+
+import (
+	"testing"
+)
+
+func TestWraparoundRisk(t *testing.T) {
+	const freezeMaxAge, multixactFreezeMaxAge = 200000000, 400000000
+	pct := func(p int64) int64 { return wraparoundLimit * p / 100 }
+
+	tests := []struct {
+		name              string
+		warning, critical float64
+		xidAge, mxidAge   int64
+		wantLevel         string
+		wantPastFreeze    bool
+	}{
+		{"young", 50, 75, 1000, 1000, "", false},
+		{"past autovacuum_freeze_max_age", 50, 75, freezeMaxAge + 1, 0, "", true},
+		{"at freeze max ages", 50, 75, freezeMaxAge, multixactFreezeMaxAge, "", false},
+		{"below warning", 50, 75, pct(50) - 1, 0, "", true},
+		{"at warning", 50, 75, pct(50), 0, "WARNING", true},
+		{"below critical", 50, 75, pct(75) - 1, 0, "WARNING", true},
+		{"at critical", 50, 75, pct(75), 0, "CRITICAL", true},
+		{"mxid age dominates", 50, 75, 1000, pct(80), "CRITICAL", true},
+		{"mxid age warning", 50, 75, pct(10), pct(60), "WARNING", true},
+		{"custom thresholds warning", 10, 20, pct(15), 0, "WARNING", true},
+		{"custom thresholds critical", 10, 20, 0, pct(25), "CRITICAL", true},
+		{"custom thresholds ok", 90, 95, pct(85), pct(85), "", true},
+	}
+
+	for _, test := range tests {
+		i := &PGInfo{}
+		i.SetWraparoundThresholds(test.warning, test.critical)
+		risk := i.wraparoundRisk(test.xidAge, test.mxidAge, freezeMaxAge, multixactFreezeMaxAge)
+		if risk.Level != test.wantLevel {
+			t.Errorf("%s: invalid level. Want %q, got %q", test.name, test.wantLevel, risk.Level)
+		}
+		if risk.PastFreezeMaxAge != test.wantPastFreeze {
+			t.Errorf("%s: invalid past freeze max age. Want %v, got %v", test.name, test.wantPastFreeze,
+				risk.PastFreezeMaxAge)
+		}
+		if want := float64(test.xidAge) * 100 / wraparoundLimit; risk.XidPct != want {
+			t.Errorf("%s: invalid xid percentage. Want %f, got %f", test.name, want, risk.XidPct)
+		}
+		if want := float64(test.mxidAge) * 100 / wraparoundLimit; risk.MxidPct != want {
+			t.Errorf("%s: invalid mxid percentage. Want %f, got %f", test.name, want, risk.MxidPct)
+		}
+	}
+}
@@ -3,9 +3,11 @@ package main
 import (
 	"database/sql"
 	"fmt"
-	"html/template"
+	"math"
 	"os"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/Percona-Lab/pt-pg-summary/internal/pginfo"
 	"github.com/Percona-Lab/pt-pg-summary/templates"
@@ -40,10 +42,19 @@ type cliOptions struct {
 	Databases           []string
 	Seconds             int
 	AllDatabases        bool
+	AllSettings         bool
 	AskPass             bool
+	IdleXactThreshold   int
 	ListEncryptedTables bool
+	StatementsDeltas    bool
+	TopCount            int
 	Verbose             bool
 	Debug               bool
+	ExactBloat          bool
+	FollowReplicas      bool
+	WaitEventsInterval  time.Duration
+	WraparoundWarning   float64
+	WraparoundCritical  float64
 }
 
 func main() {
@@ -75,6 +86,44 @@ func main() {
 	if err != nil {
 		log.Fatalf("Cannot create a data collector instance: %s", err)
 	}
+	host := opts.connOpts.Host
+	if host == "" { // lib/pq uses PGHOST if there is no host in the connection string
+		host = os.Getenv("PGHOST")
+	}
+	info.SetHost(host)
+	info.SetTopCount(opts.TopCount)
+	info.SetStatementsDeltas(opts.StatementsDeltas)
+	info.SetIdleInTransactionThreshold(opts.IdleXactThreshold)
+	info.SetWaitEventsInterval(opts.WaitEventsInterval)
+	info.SetWraparoundThresholds(opts.WraparoundWarning, opts.WraparoundCritical)
+	info.SetExactBloat(opts.ExactBloat)
+	info.SetListEncryptedTables(opts.ListEncryptedTables)
+	info.SetAllSettings(opts.AllSettings)
+
+	logger.Info("Collecting per database information (1st pass)")
+	for _, dbName := range info.DatabaseNames() {
+		conn, err := connect(buildConnString(opts.connOpts, dbName))
+		if err != nil {
+			logger.Errorf("Cannot connect to the %s database: %s", dbName, err)
+			continue
+		}
+		for _, err := range info.SnapshotPerDatabaseInfo(conn, dbName) {
+			logger.Errorf("Cannot collect information for the %s database: %s", dbName, err)
+		}
+		conn.Close()
+	}
+
+	statementsDB, statementsDBName := db, "postgres"
+	if dbName := info.FindStatementsDatabase(); dbName != "" && dbName != statementsDBName {
+		conn, err := connect(buildConnString(opts.connOpts, dbName))
+		if err != nil {
+			logger.Errorf("Cannot connect to the %s database to read pg_stat_statements: %s", dbName, err)
+		} else {
+			defer conn.Close()
+			statementsDB, statementsDBName = conn, dbName
+		}
+	}
+	info.SetStatementsConnection(statementsDB, statementsDBName)
 
 	logger.Info("Getting global information")
 	errs := info.CollectGlobalInfo(db)
@@ -95,12 +144,27 @@ func main() {
 			logger.Errorf("Cannot connect to the %s database: %s", dbName, err)
 			continue
 		}
-		if err := info.CollectPerDatabaseInfo(conn, dbName); err != nil {
+		for _, err := range info.CollectPerDatabaseInfo(conn, dbName) {
 			logger.Errorf("Cannot collect information for the %s database: %s", dbName, err)
 		}
 		conn.Close()
 	}
 
+	if opts.FollowReplicas {
+		logger.Info("Discovering the replication topology")
+		connectTo := func(host string, port int) (*sql.DB, error) {
+			co := opts.connOpts
+			co.Host, co.Port = host, port
+			logger.Infof("Connecting to %s", safeConnString(co, "postgres"))
+			return connect(buildConnString(co, "postgres"))
+		}
+		if err := info.CollectTopology(db, opts.connOpts.Host, opts.connOpts.Port, connectTo); err != nil {
+			logger.Errorf("Cannot discover the replication topology: %s", err)
+		}
+	}
+
+	// The report is plain text. text/template is used instead of html/template so query texts and
+	// settings like log_line_prefix are not HTML escaped (< as &lt;, ' as &#39;)
 	masterTmpl, err := template.New("master").Funcs(funcsMap()).Parse(templates.TPL)
 	if err != nil {
 		log.Fatal(err)
@@ -132,7 +196,25 @@ func funcsMap() template.FuncMap {
 			}
 			return s[:size]
 		},
+		// pct converts a 0-1 ratio to a percentage
+		"pct": func(ratio float64) float64 {
+			return ratio * 100
+		},
+		"bytes": prettyBytes,
+		"join":  strings.Join,
+	}
+}
+
+// prettyBytes formats a size in bytes using the same units as pg_size_pretty
+func prettyBytes(size int64) string {
+	units := []string{"bytes", "kB", "MB", "GB", "TB", "PB"}
+	value := float64(size)
+	unit := 0
+	for math.Abs(value) >= 10*1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
 	}
+	return fmt.Sprintf("%.0f %s", value, units[unit])
 }
 
 func buildConnString(opts connOpts, dbName string) string {
@@ -156,6 +238,7 @@ func buildConnString(opts connOpts, dbName string) string {
 		dbName = "postgres"
 	}
 	parts = append(parts, fmt.Sprintf("dbname=%s", dbName))
+	parts = append(parts, fmt.Sprintf("fallback_application_name=%s", pginfo.ApplicationName))
 
 	return strings.Join(parts, " ")
 }
@@ -183,6 +266,7 @@ func safeConnString(opts connOpts, dbName string) string {
 		dbName = "postgres"
 	}
 	parts = append(parts, fmt.Sprintf("dbname=%s", dbName))
+	parts = append(parts, fmt.Sprintf("fallback_application_name=%s", pginfo.ApplicationName))
 
 	return strings.Join(parts, " ")
 }
@@ -193,6 +277,8 @@ func parseCommandLineOpts(args []string) (cliOptions, error) {
 	app.Version(fmt.Sprintf("%s version %s, git commit %s, date: %s", app.Name, version, commit, date))
 	opts := cliOptions{app: app}
 
+	app.Flag("all-settings", "Show all the instance settings instead of only the ones different from their boot value").
+		Default("false").BoolVar(&opts.AllSettings)
 	app.Flag("ask-pass", "Prompt for a password when connecting to PostgreSQL").
 		Hidden().BoolVar(&opts.AskPass) // hidden because it is not implemented yet
 	app.Flag("config", "Config file").
@@ -201,11 +287,18 @@ func parseCommandLineOpts(args []string) (cliOptions, error) {
 		StringsVar(&opts.Databases)
 	app.Flag("defaults-file", "Only read PostgreSQL options from the given file").
 		Hidden().StringVar(&opts.DefaultsFile) // hidden because it is not implemented yet
+	app.Flag("exact-bloat", "Use pgstattuple_approx, if installed, to get the exact bloat of the largest tables").
+		Default("false").BoolVar(&opts.ExactBloat)
+	app.Flag("follow-replicas", "Discover the replication topology connecting to the upstream and standby servers "+
+		"with the same credentials. Standbys are expected to listen on the same port").
+		Default("false").BoolVar(&opts.FollowReplicas)
 	app.Flag("host", "Host to connect to").
 		Short('h').
 		StringVar(&opts.connOpts.Host)
-	app.Flag("list-encrypted-tables", "Include a list of the encrypted tables in all databases").
-		Hidden().BoolVar(&opts.ListEncryptedTables)
+	app.Flag("idle-in-transaction-threshold", "Seconds a session must be idle in transaction to be reported").
+		Default("60").IntVar(&opts.IdleXactThreshold)
+	app.Flag("list-encrypted-tables", "Include a list of the tables and indexes encrypted by pg_tde in all databases").
+		Default("false").BoolVar(&opts.ListEncryptedTables)
 	app.Flag("password", "Password to use when connecting").
 		Short('W').
 		StringVar(&opts.connOpts.Password)
@@ -218,11 +311,21 @@ func parseCommandLineOpts(args []string) (cliOptions, error) {
 		Hidden().StringVar(&opts.SaveSamples) // hidden because it is not implemented yet
 	app.Flag("sleep", "Seconds to sleep when gathering status counters").
 		Default("10").IntVar(&opts.Seconds)
+	app.Flag("statements-deltas", "Show pg_stat_statements counters for the sleep window instead of since the last stats reset").
+		Default("false").BoolVar(&opts.StatementsDeltas)
+	app.Flag("top", "Number of rows to show in the top N sections").
+		Default("10").IntVar(&opts.TopCount)
 	app.Flag("username", "User for login if not current user").
 		Short('U').
 		StringVar(&opts.connOpts.User)
 	app.Flag("disable-ssl", "Diable SSL for the connection").
 		Default("true").BoolVar(&opts.connOpts.DisableSSL)
+	app.Flag("wait-events-interval", "Time between wait events samples during the sleep window").
+		Default("100ms").DurationVar(&opts.WaitEventsInterval)
+	app.Flag("wraparound-warning", "Percentage of the wraparound limit to flag transaction ID ages as WARNING").
+		Default("50").Float64Var(&opts.WraparoundWarning)
+	app.Flag("wraparound-critical", "Percentage of the wraparound limit to flag transaction ID ages as CRITICAL").
+		Default("75").Float64Var(&opts.WraparoundCritical)
 	app.Flag("verbose", "Show verbose log").
 		Default("false").BoolVar(&opts.Verbose)
 	app.Flag("debug", "Show debug information in the logs").
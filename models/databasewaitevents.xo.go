@@ -10,7 +10,7 @@ import (
 // DatabaseWaitEvents represents a row from '[custom database_wait_events]'.
 type DatabaseWaitEvents struct {
 	Relname       Name           // relname
-	Relkind       uint8          // relkind
+	Relkind       string         // relkind
 	WaitEventType sql.NullString // wait_event_type
 	WaitEvent     sql.NullString // wait_event
 	Datname       Name           // datname
@@ -29,8 +29,11 @@ func GetDatabaseWaitEvents(db XODB) ([]*DatabaseWaitEvents, error) {
 		`JOIN pg_stat_activity d ON a.pid = d.pid ` +
 		`WHERE a.relation IS NOT NULL ` +
 		`AND a.database IS NOT NULL ` +
+		`AND b.datname = current_database() ` +
+		`AND NOT a.granted ` +
 		`AND (d.wait_event_type IS NOT NULL OR d.wait_event IS NOT NULL) ` +
-		`GROUP BY 1,2,3,4,5`
+		`GROUP BY 1,2,3,4,5 ` +
+		`ORDER BY 6 DESC, 1`
 
 	// run query
 	XOLog(sqlstr)
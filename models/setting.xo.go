@@ -5,8 +5,17 @@ package models
 
 // Settings
 type Setting struct {
-	Name    string // name
-	Setting string // setting
+	Name           string // name
+	Setting        string // setting
+	Unit           string // unit
+	Vartype        string // vartype
+	Category       string // category
+	Source         string // source
+	Sourcefile     string // sourcefile
+	Sourceline     int64  // sourceline
+	BootVal        string // boot_val
+	ResetVal       string // reset_val
+	PendingRestart bool   // pending_restart
 }
 
 // GetSettings runs a custom query, returning results as Setting.
@@ -14,8 +23,11 @@ func GetSettings(db XODB) ([]*Setting, error) {
 	var err error
 
 	// sql query
-	const sqlstr = `SELECT name, setting ` +
-		`FROM pg_settings`
+	const sqlstr = `SELECT name, COALESCE(setting, '') AS setting, COALESCE(unit, '') AS unit, vartype, category, source, ` +
+		`COALESCE(sourcefile, '') AS sourcefile, COALESCE(sourceline, 0) AS sourceline, ` +
+		`COALESCE(boot_val, '') AS boot_val, COALESCE(reset_val, '') AS reset_val, pending_restart ` +
+		`FROM pg_settings ` +
+		`ORDER BY category, name`
 
 	// run query
 	XOLog(sqlstr)
@@ -31,7 +43,7 @@ func GetSettings(db XODB) ([]*Setting, error) {
 		s := Setting{}
 
 		// scan
-		err = q.Scan(&s.Name, &s.Setting)
+		err = q.Scan(&s.Name, &s.Setting, &s.Unit, &s.Vartype, &s.Category, &s.Source, &s.Sourcefile, &s.Sourceline, &s.BootVal, &s.ResetVal, &s.PendingRestart)
 		if err != nil {
 			return nil, err
 		}
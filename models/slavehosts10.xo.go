@@ -5,14 +5,25 @@ package models
 
 import (
 	"database/sql"
+	"github.com/lib/pq"
 )
 
-// SlaveHosts10 represents a row from '[custom slave_hosts10]'.
+// Standbys replication status (PostgreSQL 10+)
 type SlaveHosts10 struct {
-	ApplicationName sql.NullString  // application_name
-	ClientAddr      sql.NullString  // client_addr
-	State           sql.NullString  // state
-	ByteLag         sql.NullFloat64 // byte_lag
+	ApplicationName  sql.NullString  // application_name
+	ClientAddr       sql.NullString  // client_addr
+	State            sql.NullString  // state
+	SentLag          sql.NullInt64   // sent_lag
+	WriteLag         sql.NullInt64   // write_lag
+	FlushLag         sql.NullInt64   // flush_lag
+	ReplayLag        sql.NullInt64   // replay_lag
+	WriteLagSeconds  sql.NullFloat64 // write_lag_seconds
+	FlushLagSeconds  sql.NullFloat64 // flush_lag_seconds
+	ReplayLagSeconds sql.NullFloat64 // replay_lag_seconds
+	SyncState        sql.NullString  // sync_state
+	SyncPriority     sql.NullInt64   // sync_priority
+	BackendStart     pq.NullTime     // backend_start
+	WalsenderType    string          // walsender_type
 }
 
 // GetSlaveHosts10s runs a custom query, returning results as SlaveHosts10.
@@ -20,13 +31,21 @@ func GetSlaveHosts10s(db XODB) ([]*SlaveHosts10, error) {
 	var err error
 
 	// sql query
-	var sqlstr = `SELECT application_name, client_addr, state, sent_offset - (replay_offset - (sent_lsn - replay_lsn) * 255 * 16 ^ 6 ) AS byte_lag ` +
-		`FROM ( SELECT application_name, client_addr, client_hostname, state, ` +
-		`('x' || lpad(split_part(sent_lsn::TEXT,   '/', 1), 8, '0'))::bit(32)::bigint AS sent_lsn, ` +
-		`('x' || lpad(split_part(replay_lsn::TEXT, '/', 1), 8, '0'))::bit(32)::bigint AS replay_lsn, ` +
-		`('x' || lpad(split_part(sent_lsn::TEXT,   '/', 2), 8, '0'))::bit(32)::bigint AS sent_offset, ` +
-		`('x' || lpad(split_part(replay_lsn::TEXT, '/', 2), 8, '0'))::bit(32)::bigint AS replay_offset ` +
-		`FROM pg_stat_replication ) AS s`
+	const sqlstr = `SELECT application_name, client_addr, state, ` +
+		`pg_wal_lsn_diff(c.lsn, sent_lsn)::bigint AS sent_lag, ` +
+		`pg_wal_lsn_diff(c.lsn, write_lsn)::bigint AS write_lag, ` +
+		`pg_wal_lsn_diff(c.lsn, flush_lsn)::bigint AS flush_lag, ` +
+		`pg_wal_lsn_diff(c.lsn, replay_lsn)::bigint AS replay_lag, ` +
+		`EXTRACT(epoch FROM write_lag)::float8 AS write_lag_seconds, ` +
+		`EXTRACT(epoch FROM flush_lag)::float8 AS flush_lag_seconds, ` +
+		`EXTRACT(epoch FROM replay_lag)::float8 AS replay_lag_seconds, ` +
+		`sync_state, sync_priority, backend_start, ` +
+		`CASE WHEN EXISTS (SELECT 1 FROM pg_replication_slots s WHERE s.active_pid = r.pid AND s.slot_type = 'logical') THEN 'logical' ` +
+		`WHEN state = 'backup' OR application_name IN ('pg_basebackup', 'pg_receivewal', 'pg_receivexlog') THEN 'backup' ` +
+		`ELSE 'physical' END AS walsender_type ` +
+		`FROM pg_stat_replication r ` +
+		`CROSS JOIN (SELECT CASE WHEN pg_is_in_recovery() THEN pg_last_wal_receive_lsn() ELSE pg_current_wal_lsn() END AS lsn) c ` +
+		`ORDER BY application_name, client_addr`
 
 	// run query
 	XOLog(sqlstr)
@@ -42,7 +61,7 @@ func GetSlaveHosts10s(db XODB) ([]*SlaveHosts10, error) {
 		sh := SlaveHosts10{}
 
 		// scan
-		err = q.Scan(&sh.ApplicationName, &sh.ClientAddr, &sh.State, &sh.ByteLag)
+		err = q.Scan(&sh.ApplicationName, &sh.ClientAddr, &sh.State, &sh.SentLag, &sh.WriteLag, &sh.FlushLag, &sh.ReplayLag, &sh.WriteLagSeconds, &sh.FlushLagSeconds, &sh.ReplayLagSeconds, &sh.SyncState, &sh.SyncPriority, &sh.BackendStart, &sh.WalsenderType)
 		if err != nil {
 			return nil, err
 		}
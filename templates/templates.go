@@ -1,6 +1,7 @@
 package templates
 
 var TPL = `{{define "report"}}
+{{ template "archiving" .Archiving }}
 {{ template "port_and_datadir" .PortAndDatadir }}
 {{ template "tablespaces" .Tablespaces }}
 {{ if .SlaveHosts96 -}}
@@ -8,19 +9,85 @@ var TPL = `{{define "report"}}
 {{ else if .SlaveHosts10 -}}
   {{ template "slaves_and_lag" .SlaveHosts10 }}
 {{- end }}
+{{ template "replication_slots" .ReplicationSlots }}
+{{ if .Topology -}}
+{{ template "topology" .Topology }}
+{{ end -}}
+{{ if .StandbyStatus -}}
+{{ template "standby_status" .StandbyStatus }}
+{{ end -}}
+{{ template "logical_replication" .LogicalReplication }}
 {{ template "cluster" .ClusterInfo }}
 {{ template "databases" .AllDatabases }}
+{{ template "database_wraparound" .DatabaseWraparound }}
 {{ template "index_cache_ratios" .IndexCacheHitRatio }}
 {{ template "table_cache_ratios" .TableCacheHitRatio }}
+{{ template "table_statio" .TableStatio }}
+{{ template "table_statio_by_ratio" .TableStatioByRatio }}
+{{ template "index_statio" .IndexStatio }}
+{{ template "index_statio_by_ratio" .IndexStatioByRatio }}
 {{ template "global_wait_events" .GlobalWaitEvents }}
+{{ template "database_wait_events" .DatabaseWaitEvents }}
+{{ template "wait_events_profile" .WaitEventsProfile }}
+{{ template "top_statements" . }}
 {{ template "connected_clients" .ConnectedClients }}
+{{ template "connection_security" .ConnectionSecurity }}
+{{ template "long_transactions" .LongTransactions }}
+{{ template "idle_in_transaction" .IdleInTransaction }}
+{{ template "xmin_holders" .XminHolders }}
+{{ template "blocking_trees" .BlockingTrees }}
 {{ template "counters_header" .Sleep }}
 {{ template "counters" .Counters }}
 {{ template "table_access" .TableAccess }}
-{{ template "settings" .Settings }}
+{{ template "table_activity" .TableActivity }}
+{{ template "schema_inventory" .SchemaInventory }}
+{{ template "extensions" .Extensions }}
+{{ template "server_extensions" . }}
+{{ template "encrypted_tables" .EncryptedTables }}
+{{ template "largest_tables" .LargestTables }}
+{{ template "vacuum_health" .VacuumHealth }}
+{{ template "table_wraparound" .TableWraparound }}
+{{ template "sequences" .Sequences }}
+{{ template "bloat" .Bloat }}
+{{ template "index_health" .IndexHealth }}
+{{ template "roles_audit" .RolesAudit }}
+{{ template "public_schema" .PublicSchema }}
+{{ template "hba_rules" .HbaRules }}
+{{ template "settings" .SettingsReport }}
 {{ template "processes" .Processes }}
 {{ end }} {{/* end "report" */}}` +
 	`
+{{ define "archiving" -}}
+##### --- WAL archiving --- ####
+{{ if . -}}
+{{ if .Failing -}}
+WARNING: WAL archiving is failing. The last failure is more recent than the last archived WAL
+{{ end -}}
+{{ if .Lagging -}}
+WARNING: {{ .ReadyFiles }} WAL files are waiting to be archived
+{{ end -}}
++------------------------------------------------------------------------------------------------------+
+ Archive mode           : {{ .ArchiveMode }}
+ Archive command        : {{ .ArchiveCommand }}
+{{- if .ArchiveLibrary }}
+ Archive library        : {{ .ArchiveLibrary }}
+{{- end }}
+ Archived WAL files     : {{ .ArchivedCount }}
+ Last archived WAL      : {{ .LastArchivedWal }}
+ Last archived time     : {{ template "nulltime" .LastArchivedTime }}
+ Failed attempts        : {{ .FailedCount }}
+ Last failed WAL        : {{ .LastFailedWal }}
+ Last failed time       : {{ template "nulltime" .LastFailedTime }}
+ Stats reset            : {{ template "nulltime" .StatsReset }}
+ Files ready to archive : {{ if ge .ReadyFiles 0 }}{{ .ReadyFiles }}{{ else }}unknown (requires pg_monitor on PostgreSQL 12+ or running the tool on the server host){{ end }}
+ Exclusive backup       : {{ if .InBackup }}in progress since {{ template "nulltime" .BackupStartTime }}{{ else }}no{{ end }}
++------------------------------------------------------------------------------------------------------+
+{{ else -}}
+No stats available
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`
 {{ define "port_and_datadir" -}}
 ##### --- Database Port and Data_Directory --- ####
 +----------------------+----------------------------------------------------+
@@ -44,20 +111,179 @@ var TPL = `{{define "report"}}
 	`{{ define "slaves_and_lag" -}}
 ##### --- Slave and the lag with Master --- ####
 {{ if . -}}
-+----------------------+----------------------+----------------------------------------------------+
-|  Application Name    |    Client Address    |           State                |      Lag          |
-+----------------------+----------------------+----------------------------------------------------+
++----------------------+----------------------+------------+-------------+------------+------------+------------+------------+------------+------------+------------+---------------------+
+|  Application Name    |    Client Address    |   State    | Sync (prio) |  Sent Lag  |  Write Lag |  Flush Lag | Replay Lag |  Write (s) |  Flush (s) | Replay (s) |    Backend Start    |
++----------------------+----------------------+------------+-------------+------------+------------+------------+------------+------------+------------+------------+---------------------+
 {{ range . -}}` +
-	`| {{ printf "%-20s" .ApplicationName }} ` +
-	`| {{ printf "%-20s" .ClientAddr }} ` +
-	`| {{ printf "%-50s" .State }} ` +
-	`|  {{ printf "% 4.2f" .ByteLag }}` +
-	`{{ end -}} {{/* end define */}}
-+----------------------+----------------------+----------------------------------------------------+
-{{- else -}}
+	`| {{ printf "%-20s" (trim .ApplicationName.String 20) }} ` +
+	`| {{ printf "%-20s" (trim .ClientAddr.String 20) }} ` +
+	`| {{ printf "%-10s" (trim .State.String 10) }} ` +
+	`| {{ printf "%-11s" (printf "%s (%d)" .SyncState.String .SyncPriority.Int64) }} ` +
+	`| {{ printf "%10s" (bytes .SentLag.Int64) }} ` +
+	`| {{ printf "%10s" (bytes .WriteLag.Int64) }} ` +
+	`| {{ printf "%10s" (bytes .FlushLag.Int64) }} ` +
+	`| {{ printf "%10s" (bytes .ReplayLag.Int64) }} ` +
+	`| {{ template "lag_seconds" .WriteLagSeconds }} ` +
+	`| {{ template "lag_seconds" .FlushLagSeconds }} ` +
+	`| {{ template "lag_seconds" .ReplayLagSeconds }} ` +
+	`| {{ template "nulltime" .BackendStart }} |` + "\n" +
+	`{{ end -}}
++----------------------+----------------------+------------+-------------+------------+------------+------------+------------+------------+------------+------------+---------------------+
+{{ else -}}
 There are no slave hosts
 {{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "lag_seconds" -}}
+{{ if .Valid }}{{ printf "% 10.3f" .Float64 }}{{ else }}{{ printf "%10s" "" }}{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "replication_slots" -}}
+##### --- Replication slots --- ####
+{{ if . -}}
++----------------------+----------+----------------------+----------------------+--------+---------+------------------+------------------+--------------+------------+---------------+
+|      Slot Name       |   Type   |        Plugin        |       Database       | Active |   PID   |   Restart LSN    | Confirmed Flush  | Retained WAL | WAL Status | Safe WAL Size |
++----------------------+----------+----------------------+----------------------+--------+---------+------------------+------------------+--------------+------------+---------------+
+{{ range . -}}` +
+	`| {{ printf "%-20s" (trim .SlotName 20) }} ` +
+	`| {{ printf "%-8s" .SlotType }} ` +
+	`| {{ printf "%-20s" (trim .Plugin 20) }} ` +
+	`| {{ printf "%-20s" (trim .Database 20) }} ` +
+	`| {{ printf "%-6v" .Active }} ` +
+	`| {{ if .ActivePid.Valid }}{{ printf "% 7d" .ActivePid.Int64 }}{{ else }}{{ printf "%7s" "" }}{{ end }} ` +
+	`| {{ printf "%-16s" .RestartLsn }} ` +
+	`| {{ printf "%-16s" .ConfirmedFlushLsn }} ` +
+	`| {{ printf "%12s" (bytes .RetainedBytes) }} ` +
+	`| {{ printf "%-10s" .WalStatus.String }} ` +
+	`| {{ if .SafeWalSize.Valid }}{{ printf "%13s" (bytes .SafeWalSize.Int64) }}{{ else }}{{ printf "%13s" "" }}{{ end }} |` + "\n" +
+	`{{ end -}}
++----------------------+----------+----------------------+----------------------+--------+---------+------------------+------------------+--------------+------------+---------------+
+{{ else -}}
+There are no replication slots
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "topology" -}}
+##### --- Replication topology --- ####
+{{ range . -}}
+{{ .Prefix }}{{ or .Server.Host "localhost" }}:{{ .Server.Port }} ({{ .Server.Role }}){{ if .Server.Current }} *{{ end }}
+{{- if .Level }} | App: {{ .Server.ApplicationName }} | State: {{ .Server.State }} | Sync: {{ .Server.SyncState }}` +
+	` | Replay lag: {{ bytes .Server.ReplayLag }}{{ if .Server.ReplayLagSeconds.Valid }} ({{ printf "%.3f" .Server.ReplayLagSeconds.Float64 }}s){{ end }}{{ end }}
+{{- if .Server.Error }} | Error: {{ .Server.Error }}{{ end }}
+{{ end -}}
+(*) this server
+Only physical standbys are followed, on the same port as this server. Standbys connected through
+a Unix socket or listening on another port are shown as unknown and their own standbys are missing.
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "standby_status" -}}
+##### --- Standby recovery status --- ####
++------------------------------------------------------------------------------------------------------+
+ Last received LSN      : {{ .Recovery.ReceiveLsn }}
+ Last replayed LSN      : {{ .Recovery.ReplayLsn }}
+ Replay lag             : {{ bytes .Recovery.ReplayLag }}
+ Last replayed xact time: {{ template "nulltime" .Recovery.LastReplayTime }}
+{{- if .Recovery.ReplayDelaySeconds.Valid }}
+ Replay delay           : {{ printf "%.3f" .Recovery.ReplayDelaySeconds.Float64 }} seconds
+{{- end }}
+ Replay paused          : {{ .Recovery.IsPaused }}
+ Primary conninfo       : {{ .PrimaryConninfo }}
+ Primary slot name      : {{ .PrimarySlotName }}
++------------------------------------------------------------------------------------------------------+
+{{ range .WalReceivers -}}
+WAL receiver
+ PID                    : {{ .Pid }}
+ Status                 : {{ .Status }}
+ Slot name              : {{ .SlotName }}
+ Flushed LSN            : {{ .FlushedLsn }}
+ Latest end LSN         : {{ .LatestEndLsn }}
+ Latest end time        : {{ template "nulltime" .LatestEndTime }}
+ Last message sent      : {{ template "nulltime" .LastMsgSendTime }}
+ Last message received  : {{ template "nulltime" .LastMsgReceiptTime }}
+ Conninfo               : {{ .Conninfo }}
++------------------------------------------------------------------------------------------------------+
+{{ else -}}
+There is no WAL receiver running
+{{ end -}}
+Queries canceled due to conflicts with recovery
++----------------------+------------+------------+------------+------------+------------+
+|       Database       | Tablespace |    Lock    |  Snapshot  |  Bufferpin |  Deadlock  |
++----------------------+------------+------------+------------+------------+------------+
+{{ range .Conflicts -}}` +
+	`| {{ printf "%-20s" .Datname }} ` +
+	`| {{ printf "% 10d" .ConflTablespace }} ` +
+	`| {{ printf "% 10d" .ConflLock }} ` +
+	`| {{ printf "% 10d" .ConflSnapshot }} ` +
+	`| {{ printf "% 10d" .ConflBufferpin }} ` +
+	`| {{ printf "% 10d" .ConflDeadlock }} |` + "\n" +
+	`{{ end -}}
++----------------------+------------+------------+------------+------------+------------+
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "logical_replication" -}}
+##### --- Logical replication per database --- ####
+{{ range $dbname, $lr := . -}}
+Database: {{ $dbname }}
+{{ if or .Publications .Subscriptions -}}
+{{ if .Publications -}}
+Publications
++--------------------------------+----------------------+------------+---------------------------------------+---------+
+|              Name              |        Owner         | All Tables | Insert / Update / Delete / Truncate   |  Tables |
++--------------------------------+----------------------+------------+---------------------------------------+---------+
+{{ range .Publications -}}` +
+	`| {{ printf "%-30s" (trim .Pubname 30) }} ` +
+	`| {{ printf "%-20s" (trim .Owner 20) }} ` +
+	`| {{ printf "%-10v" .Puballtables }} ` +
+	`| {{ printf "%-37s" (printf "%v / %v / %v / %v" .Pubinsert .Pubupdate .Pubdelete .Pubtruncate) }} ` +
+	`| {{ printf "% 7d" .Tables }} |` + "\n" +
+	`{{ end -}}
++--------------------------------+----------------------+------------+---------------------------------------+---------+
 {{ end -}}
+{{ if .PublicationTables -}}
+Published tables
++--------------------------------+------------------------------------------+
+|          Publication           |                  Table                   |
++--------------------------------+------------------------------------------+
+{{ range .PublicationTables -}}` +
+	`| {{ printf "%-30s" (trim .Pubname 30) }} ` +
+	`| {{ printf "%-40s" (trim (printf "%s.%s" .Schemaname .Tablename) 40) }} |` + "\n" +
+	`{{ end -}}
++--------------------------------+------------------------------------------+
+{{ end -}}
+{{ range .Subscriptions -}}
+Subscription: {{ .Subname }}
+ Owner                  : {{ .Owner }}
+ Enabled                : {{ .Subenabled }}
+ Slot name              : {{ .Subslotname }}
+ Publications           : {{ .Publications }}
+ Apply worker PID       : {{ if .Pid.Valid }}{{ .Pid.Int64 }}{{ else }}not running{{ end }}
+ Received LSN           : {{ .ReceivedLsn }}
+ Latest end LSN         : {{ .LatestEndLsn }}
+ Latest end time        : {{ template "nulltime" .LatestEndTime }}
+ Last message received  : {{ template "nulltime" .LastMsgReceiptTime }}
+ Table sync workers     : {{ .SyncWorkers }}
+{{- if .ApplyErrorCount.Valid }}
+ Apply errors           : {{ .ApplyErrorCount.Int64 }}
+ Sync errors            : {{ .SyncErrorCount.Int64 }}
+{{- end }}
+{{ end -}}
+{{ if .SyncingTables -}}
+Subscribed tables not ready (i = initialize, d = data copy, f = finished copy, s = synchronized)
++--------------------------------+------------------------------------------+-------+
+|          Subscription          |                  Table                   | State |
++--------------------------------+------------------------------------------+-------+
+{{ range .SyncingTables -}}` +
+	`| {{ printf "%-30s" (trim .Subname 30) }} ` +
+	`| {{ printf "%-40s" (trim (printf "%s.%s" .Schemaname .Relname) 40) }} ` +
+	`|   {{ printf "%1s" .State }}   |` + "\n" +
+	`{{ end -}}
++--------------------------------+------------------------------------------+-------+
+{{ end -}}
+{{ else -}}
+There are no publications or subscriptions
+{{ end -}}
+{{ end -}}
+{{ end -}} {{/* end define */}}
 ` +
 	`{{ define "cluster" -}}
 ##### --- Cluster Information --- ####
@@ -88,6 +314,27 @@ There is no Cluster info
 {{ end -}}
 +----------------------+------------+
 {{ end }} {{/* end define */}}
+` +
+	`{{ define "database_wraparound" -}}
+##### --- Transaction ID and MultiXact wraparound per database --- ####
+{{ if . -}}
++----------------------+--------------+---------+--------------+---------+--------------------+----------+
+|       Dat Name       |    XID Age   |  XID %  |   MXID Age   |  MXID % | Past Freeze MaxAge |  Status  |
++----------------------+--------------+---------+--------------+---------+--------------------+----------+
+{{ range . -}}` +
+	`| {{ printf "%-20s" .Datname }} ` +
+	`| {{ printf "% 12d" .XidAge }} ` +
+	`| {{ printf "% 7.2f" .XidPct }} ` +
+	`| {{ printf "% 12d" .MxidAge }} ` +
+	`| {{ printf "% 7.2f" .MxidPct }} ` +
+	`| {{ printf "%-18v" .PastFreezeMaxAge }} ` +
+	`| {{ printf "%-8s" .Level }} |` + "\n" +
+	`{{ end -}}
++----------------------+--------------+---------+--------------+---------+--------------------+----------+
+{{ else -}}
+  No stats available
+{{ end -}}
+{{- end -}} {{/* end define */}}
 ` +
 	`{{ define "index_cache_ratios" -}}
 ##### --- Index Cache Hit Ratios --- ####
@@ -95,7 +342,7 @@ There is no Cluster info
 {{ range $dbname, $value := . }}
 Database: {{ $dbname }}
 +----------------------+------------+
-|      Index Name      |    Ratio   |
+|        Metric        |    Ratio   |
 +----------------------+------------+
 | {{ printf "%-20s" .Name }} |     {{ printf "% 5.2f" .Ratio.Float64 }}  |
 +----------------------+------------+
@@ -104,6 +351,69 @@ Database: {{ $dbname }}
 {{ end -}}
 {{ end -}}
 {{ end -}} {{/* end define */}}
+` +
+	`{{ define "table_statio" -}}
+##### --- Tables with the most disk reads per database --- ####
+{{ template "table_statio_rows" . }}
+{{- end -}} {{/* end define */}}
+` +
+	`{{ define "table_statio_by_ratio" -}}
+##### --- Tables with the worst cache hit ratio per database --- ####
+{{ template "table_statio_rows" . }}
+{{- end -}} {{/* end define */}}
+` +
+	`{{ define "table_statio_rows" -}}
+{{ range $dbname, $values := . -}}
+Database: {{ $dbname }}
+{{ if $values -}}
++------------------------------------------+----------------+----------------+----------------+----------------+---------+
+|                  Table                   | Heap Blks Read | Heap Blks Hit  | Idx Blks Read  |  Idx Blks Hit  |  Hit %  |
++------------------------------------------+----------------+----------------+----------------+----------------+---------+
+{{ range $values -}}` +
+	`| {{ printf "%-40s" (trim (printf "%s.%s" .Schemaname .Relname) 40) }} ` +
+	`| {{ printf "% 14d" .HeapBlksRead }} ` +
+	`| {{ printf "% 14d" .HeapBlksHit }} ` +
+	`| {{ printf "% 14d" .IdxBlksRead }} ` +
+	`| {{ printf "% 14d" .IdxBlksHit }} ` +
+	`| {{ printf "% 7.2f" (pct .Ratio) }} |` + "\n" +
+	`{{ end -}}
++------------------------------------------+----------------+----------------+----------------+----------------+---------+
+{{ else -}}
+  No stats available
+{{ end -}}
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "index_statio" -}}
+##### --- Indexes with the most disk reads per database --- ####
+{{ template "index_statio_rows" . }}
+{{- end -}} {{/* end define */}}
+` +
+	`{{ define "index_statio_by_ratio" -}}
+##### --- Indexes with the worst cache hit ratio per database --- ####
+{{ template "index_statio_rows" . }}
+{{- end -}} {{/* end define */}}
+` +
+	`{{ define "index_statio_rows" -}}
+{{ range $dbname, $values := . -}}
+Database: {{ $dbname }}
+{{ if $values -}}
++------------------------------------------+------------------------------------------+----------------+----------------+---------+
+|                  Table                   |                  Index                   |  Idx Blks Read |  Idx Blks Hit  |  Hit %  |
++------------------------------------------+------------------------------------------+----------------+----------------+---------+
+{{ range $values -}}` +
+	`| {{ printf "%-40s" (trim (printf "%s.%s" .Schemaname .Relname) 40) }} ` +
+	`| {{ printf "%-40s" (trim .Indexrelname 40) }} ` +
+	`| {{ printf "% 14d" .IdxBlksRead }} ` +
+	`| {{ printf "% 14d" .IdxBlksHit }} ` +
+	`| {{ printf "% 7.2f" (pct .Ratio) }} |` + "\n" +
+	`{{ end -}}
++------------------------------------------+------------------------------------------+----------------+----------------+---------+
+{{ else -}}
+  No stats available
+{{ end -}}
+{{ end -}}
+{{ end -}} {{/* end define */}}
 ` +
 	`{{ define "table_cache_ratios" -}}
 ##### --- Table Cache Hit Ratios --- ####
@@ -111,7 +421,7 @@ Database: {{ $dbname }}
 {{ range $dbname, $value := . -}}
 Database: {{ $dbname }}
 +----------------------+------------+
-|      Index Name      |    Ratio   |
+|        Metric        |    Ratio   |
 +----------------------+------------+
 | {{ printf "%-20s" .Name }} |      {{ printf "%5.2f" .Ratio.Float64 }} |
 +----------------------+------------+
@@ -135,6 +445,140 @@ Database: {{ $dbname }}
   No stats available
 {{ end -}}
 {{- end -}} {{/* end define */}}
+` +
+	`{{ define "database_wait_events" -}}
+##### --- Sessions waiting for a lock per relation per database --- ####
+{{ if . -}}
+{{ range $dbname, $values := . -}}
+Database: {{ $dbname }}
+{{ if $values -}}
++----------------------------------------------------+------+----------------------+----------------------+---------+
+|                       Relname                      | Kind |   Wait Event Type    |        Event         |  Count  |
++----------------------------------------------------+------+----------------------+----------------------+---------+
+{{ range $values -}}
+| {{ printf "%-50s" .Relname }} ` +
+	`|   {{ printf "%1s" .Relkind }}  ` +
+	`| {{ printf "%-20s" .WaitEventType.String }} ` +
+	`| {{ printf "%-20s" .WaitEvent.String }} ` +
+	`| {{ printf "% 7d" .Count.Int64 }} |
+{{ end -}}
++----------------------------------------------------+------+----------------------+----------------------+---------+
+{{ else -}}
+  No sessions waiting on relations
+{{ end -}}
+{{ end -}}
+{{ else -}}
+  No stats available
+{{ end -}}
+{{- end -}} {{/* end define */}}
+` +
+	`{{ define "wait_events_profile" -}}
+##### --- Wait_events sampled during the sleep window --- ####
+{{ if . -}}
+{{ .Samples }} samples taken every {{ .Interval }}
+{{ if .ByEvent -}}
++----------------------+--------------------------------+---------+---------+
+|   Wait Event Type    |             Event              |  Count  |    %    |
++----------------------+--------------------------------+---------+---------+
+{{ range .ByEvent -}}` +
+	`| {{ printf "%-20s" .WaitEventType }} ` +
+	`| {{ printf "%-30s" .WaitEvent }} ` +
+	`| {{ printf "% 7d" .Count }} ` +
+	`| {{ printf "% 7.2f" .Percent }} |` + "\n" +
+	`{{ end -}}
++----------------------+--------------------------------+---------+---------+
+Per database and backend type
++----------------------+------------------------------+----------------------+--------------------------------+---------+---------+
+|       Database       |         Backend Type         |   Wait Event Type    |             Event              |  Count  |    %    |
++----------------------+------------------------------+----------------------+--------------------------------+---------+---------+
+{{ range .ByDatabase -}}` +
+	`| {{ printf "%-20s" .Datname }} ` +
+	`| {{ printf "%-28s" .BackendType }} ` +
+	`| {{ printf "%-20s" .WaitEventType }} ` +
+	`| {{ printf "%-30s" .WaitEvent }} ` +
+	`| {{ printf "% 7d" .Count }} ` +
+	`| {{ printf "% 7.2f" .Percent }} |` + "\n" +
+	`{{ end -}}
++----------------------+------------------------------+----------------------+--------------------------------+---------+---------+
+{{ else -}}
+  No active sessions found
+{{ end -}}
+{{ else -}}
+  No stats available
+{{ end -}}
+{{- end -}} {{/* end define */}}
+` +
+	`{{ define "top_statements" -}}
+##### --- Top statements from pg_stat_statements --- ####
+{{ with .TopStatements -}}
+{{ if .IsDelta -}}
+Values collected during the sleep window
+{{ else -}}
+Values collected since the last stats reset
+{{ end -}}
+Top statements by total time
+{{ template "statements" .ByTotalTime }}
+Top statements by mean time
+{{ template "statements" .ByMeanTime }}
+Top statements by calls
+{{ template "statements" .ByCalls }}
+Top statements by rows
+{{ template "statements" .ByRows }}
+Top statements by shared blocks read
+{{ template "statements" .BySharedBlksRead }}
+Top statements by temp blocks
+{{ template "statements" .ByTempBlks }}
+{{ else -}}
+  pg_stat_statements extension is not installed in database {{ .StatementsDatabase }}
+{{ end -}}
+{{- end -}} {{/* end define */}}
+` +
+	`{{ define "statements" -}}
++------------+--------------+------------+------------+-------------+------------+--------------+----------------------+----------------------+
+|   Calls    |  Total (ms)  | Mean (ms)  |    Rows    | Shared Read | Temp Read  | Temp Written |       Database       |         User         |
++------------+--------------+------------+------------+-------------+------------+--------------+----------------------+----------------------+
+{{ range . -}}` +
+	`| {{ printf "% 10d" .Calls }} ` +
+	`| {{ printf "% 12.2f" .TotalTime }} ` +
+	`| {{ printf "% 10.2f" .MeanTime }} ` +
+	`| {{ printf "% 10d" .Rows }} ` +
+	`| {{ printf "% 11d" .SharedBlksRead }} ` +
+	`| {{ printf "% 10d" .TempBlksRead }} ` +
+	`| {{ printf "% 12d" .TempBlksWritten }} ` +
+	`| {{ printf "%-20s" .Datname }} ` +
+	`| {{ printf "%-20s" .Usename }} |` + "\n" +
+	`  Query: {{ .Query }}` + "\n" +
+	`{{ end -}}
++------------+--------------+------------+------------+-------------+------------+--------------+----------------------+----------------------+
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "connection_security" -}}
+##### --- Connection security --- ####
+{{ if . -}}
+{{ range .Settings -}}
+{{ printf "%-25s" .Name }}: {{ .Setting }}
+{{ end -}}
+Network sessions encrypted: {{ .Encrypted }}, cleartext: {{ .Cleartext }}. Unix socket sessions: {{ .Local }}
+{{ if .Unknown -}}
+Sessions with hidden client details (pg_read_all_stats is needed to see them): {{ .Unknown }}
+{{ end -}}
++----------------------+--------------------------------+-------+-------+---------+----------------------------------------+---------+
+|         User         |             Client             |  SSL  |  GSS  | Version |                 Cipher                 |  Count  |
++----------------------+--------------------------------+-------+-------+---------+----------------------------------------+---------+
+{{ range .Clients -}}` +
+	`| {{ printf "%-20s" (trim .Usename 20) }} ` +
+	`| {{ printf "%-30s" (trim .Client 30) }} ` +
+	`| {{ template "yes_no" .Ssl }} ` +
+	`| {{ template "yes_no" .GssEncrypted }} ` +
+	`| {{ printf "%-7s" .Version }} ` +
+	`| {{ printf "%-38s" (trim .Cipher 38) }} ` +
+	`| {{ printf "%7d" .Count }} |` + "\n" +
+	`{{ end -}}
++----------------------+--------------------------------+-------+-------+---------+----------------------------------------+---------+
+{{ else -}}
+No stats available
+{{ end -}}
+{{ end -}} {{/* end define */}}
 ` +
 	`{{ define "connected_clients" -}}
 ##### --- List of users and client_addr or client_hostname connected to --all-databases --- ####
@@ -153,6 +597,83 @@ Database: {{ $dbname }}
   No stats available
 {{ end -}}
 {{- end -}} {{/* end define */}}
+` +
+
+	`{{ define "long_transactions" -}}
+##### --- Oldest open transactions --- ####
+{{ if . -}}
+{{ template "transactions" . }}
+{{- else -}}
+  There are no open transactions
+{{ end -}}
+{{- end -}} {{/* end define */}}
+` +
+	`{{ define "idle_in_transaction" -}}
+##### --- Sessions idle in transaction --- ####
+{{ if . -}}
+{{ template "transactions" . }}
+{{- else -}}
+  There are no sessions idle in transaction
+{{ end -}}
+{{- end -}} {{/* end define */}}
+` +
+	`{{ define "transactions" -}}
++---------+----------------------+----------------------+----------------------+----------------------+--------------------------------+--------------+--------------+
+|   PID   |       Database       |         User         |     Application      |    Client Address    |             State              |  Xact Age(s) | State Age(s) |
++---------+----------------------+----------------------+----------------------+----------------------+--------------------------------+--------------+--------------+
+{{ range . -}}` +
+	`| {{ printf "% 7d" .Pid }} ` +
+	`| {{ printf "%-20s" .Datname.String }} ` +
+	`| {{ printf "%-20s" .Usename.String }} ` +
+	`| {{ printf "%-20s" (trim .ApplicationName.String 20) }} ` +
+	`| {{ printf "%-20s" .ClientAddr.String }} ` +
+	`| {{ printf "%-30s" .State.String }} ` +
+	`| {{ printf "% 12.0f" .XactAge }} ` +
+	`| {{ printf "% 12.0f" .StateAge }} |` + "\n" +
+	`  Query: {{ .Query }}` + "\n" +
+	`{{ end -}}
++---------+----------------------+----------------------+----------------------+----------------------+--------------------------------+--------------+--------------+
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "xmin_holders" -}}
+##### --- Oldest xmin horizon holders --- ####
+{{ if . -}}
++----------------------------+------------------------------------------+----------------------+--------------+--------------+
+|            Kind            |                  Holder                  |       Database       |     Xmin     |   Xmin Age   |
++----------------------------+------------------------------------------+----------------------+--------------+--------------+
+{{ range . -}}` +
+	`| {{ printf "%-26s" .Kind }} ` +
+	`| {{ printf "%-40s" (trim .Holder 40) }} ` +
+	`| {{ printf "%-20s" .Datname.String }} ` +
+	`| {{ printf "%12s" .Xmin }} ` +
+	`| {{ printf "% 12d" .XminAge }} |` + "\n" +
+	`{{ end -}}
++----------------------------+------------------------------------------+----------------------+--------------+--------------+
+{{ else -}}
+  Nothing is holding back the xmin horizon
+{{ end -}}
+{{- end -}} {{/* end define */}}
+` +
+
+	`{{ define "blocking_trees" -}}
+##### --- Blocking lock chains --- ####
+{{ if . -}}
++------------------------------------------------------------------------------------------------------+
+{{ range . -}}
+{{ .Prefix }}PID: {{ .Session.Pid }}` +
+	`{{ if eq .Level 0 }} (blocker){{ else }} (waiting){{ end }}` +
+	` | User: {{ .Session.Usename.String }}` +
+	` | Application: {{ trim .Session.ApplicationName.String 30 }}` +
+	` | State: {{ .Session.State.String }}` +
+	` | Xact Age: {{ printf "%.0f" .Session.XactAge }}s` +
+	`{{ if .Session.WaitEventType.Valid }} | Wait: {{ .Session.WaitEventType.String }}:{{ .Session.WaitEvent.String }}{{ end }}` + "\n" +
+	`{{ .Indent }}  Query: {{ .Session.Query }}` + "\n" +
+	`{{ end -}}
++------------------------------------------------------------------------------------------------------+
+{{ else -}}
+  There are no blocked sessions
+{{ end -}}
+{{- end -}} {{/* end define */}}
 ` +
 
 	/*
@@ -248,7 +769,7 @@ Database: {{ $dbname }}
 
 	`{{ define "table_access" -}}` +
 
-	"##### --- Table access per database --- ####\n" +
+	"##### --- Locks held per relation per database --- ####\n" +
 	`{{ range $dbname, $values := . -}}` +
 	"Database: {{ $dbname }}\n" +
 	"+----------------------------------------------------" +
@@ -277,19 +798,482 @@ Database: {{ $dbname }}
 	"{{ end -}}" +
 	"{{ end }}" +
 
+	`{{ define "table_activity" -}}
+##### --- Busiest tables per database (rates per second) --- ####
+{{ range $dbname, $activity := . -}}
+Database: {{ $dbname }} ({{ printf "%.0f" .Seconds }} seconds between snapshots)
+By sequential scans
+{{ template "table_rates" .BySeqScan -}}
+By index scans
+{{ template "table_rates" .ByIdxScan -}}
+By inserts
+{{ template "table_rates" .ByInserts -}}
+By updates
+{{ template "table_rates" .ByUpdates -}}
+By deletes
+{{ template "table_rates" .ByDeletes -}}
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "table_rates" -}}
+{{ if . -}}
++------------------------------------------+------------+--------------+------------+--------------+------------+------------+---------+------------+
+|                  Table                   |  Seq Scans | Seq Tup Read | Idx Scans  |  Idx Fetched |  Inserts   |  Updates   |  HOT %  |  Deletes   |
++------------------------------------------+------------+--------------+------------+--------------+------------+------------+---------+------------+
+{{ range . -}}` +
+	`| {{ printf "%-40s" (trim (printf "%s.%s" .Schemaname .Relname) 40) }} ` +
+	`| {{ printf "% 10.2f" .SeqScan }} ` +
+	`| {{ printf "% 12.2f" .SeqTupRead }} ` +
+	`| {{ printf "% 10.2f" .IdxScan }} ` +
+	`| {{ printf "% 12.2f" .IdxTupFetch }} ` +
+	`| {{ printf "% 10.2f" .Inserts }} ` +
+	`| {{ printf "% 10.2f" .Updates }} ` +
+	`| {{ printf "% 7.2f" (pct .HotRatio) }} ` +
+	`| {{ printf "% 10.2f" .Deletes }} |` + "\n" +
+	`{{ end -}}
++------------------------------------------+------------+--------------+------------+--------------+------------+------------+---------+------------+
+{{ else -}}
+  No activity
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "schema_inventory" -}}
+##### --- Schema objects per database --- ####
+{{ if . -}}
++----------------------+---------+---------+-------------+---------+---------+-----------+-----------+----------+---------------+
+|       Database       | Schemas |  Tables | Partitioned |  Views  | MViews  | Sequences | Functions | Triggers | Large Objects |
++----------------------+---------+---------+-------------+---------+---------+-----------+-----------+----------+---------------+
+{{ range $dbname, $inventory := . -}}` +
+	`| {{ printf "%-20s" $dbname }} ` +
+	`| {{ printf "% 7d" .Schemas }} ` +
+	`| {{ printf "% 7d" .Tables }} ` +
+	`| {{ printf "% 11d" .PartitionedTables }} ` +
+	`| {{ printf "% 7d" .Views }} ` +
+	`| {{ printf "% 7d" .MaterializedViews }} ` +
+	`| {{ printf "% 9d" .Sequences }} ` +
+	`| {{ printf "% 9d" .Functions }} ` +
+	`| {{ printf "% 8d" .Triggers }} ` +
+	`| {{ printf "% 13d" .LargeObjects }} |` + "\n" +
+	`{{ end -}}
++----------------------+---------+---------+-------------+---------+---------+-----------+-----------+----------+---------------+
+{{ else -}}
+  No stats available
+{{ end -}}
+{{- end -}} {{/* end define */}}
+` +
+	`{{ define "extensions" -}}
+##### --- Installed extensions per database --- ####
+{{ range $dbname, $values := . -}}
+Database: {{ $dbname }}
+{{ if $values -}}
++--------------------------------+------------------------------+------------+------------+---------+
+|           Extension            |            Schema            |  Installed |  Default   | Upgrade |
++--------------------------------+------------------------------+------------+------------+---------+
+{{ range $values -}}` +
+	`| {{ printf "%-30s" (trim .Extname 30) }} ` +
+	`| {{ printf "%-28s" (trim .Schemaname 28) }} ` +
+	`| {{ printf "%-10s" .Extversion }} ` +
+	`| {{ printf "%-10s" .DefaultVersion }} ` +
+	`| {{ if .UpgradePending }}   YES  {{ else }}        {{ end }}|` + "\n" +
+	`{{ end -}}
++--------------------------------+------------------------------+------------+------------+---------+
+{{ else -}}
+No extensions installed
+{{ end -}}
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "encrypted_tables" -}}
+{{ if . -}}
+##### --- Encrypted tables per database (pg_tde) --- ####
+{{ range $dbname, $values := . -}}
+Database: {{ $dbname }}
+{{ if $values.Extension -}}
+pg_tde {{ $values.Extension.Extversion }} installed in the {{ $values.Extension.Schemaname }} schema
+{{ if not $values.Preloaded -}}
+WARNING: pg_tde is not in shared_preload_libraries
+{{ end -}}
+{{ range $values.KeyProviders -}}
+Key provider: {{ .ProviderName }} ({{ .ProviderType }}, {{ .Scope }})
+{{ else -}}
+No key providers
+{{ end -}}
+Encrypted relations:
+{{ template "encryption_status" $values.Encrypted -}}
+Tables not encrypted in the same schemas:
+{{ template "encryption_status" $values.NotEncrypted -}}
+{{ else -}}
+pg_tde is not installed
+{{ end -}}
+{{ end -}}
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "encryption_status" -}}
+{{ if . -}}
++--------------------------------+--------------------------------+-------------------+--------------------------------+------------+
+|             Schema             |            Relation            |       Kind        |             Table              |    Size    |
++--------------------------------+--------------------------------+-------------------+--------------------------------+------------+
+{{ range . -}}` +
+	`| {{ printf "%-30s" (trim .Schemaname 30) }} ` +
+	`| {{ printf "%-30s" (trim .Relname 30) }} ` +
+	`| {{ printf "%-17s" .Kind }} ` +
+	`| {{ printf "%-30s" (trim .TableName 30) }} ` +
+	`| {{ printf "%10s" (bytes .Size) }} |` + "\n" +
+	`{{ end -}}
++--------------------------------+--------------------------------+-------------------+--------------------------------+------------+
+{{ else -}}
+None
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "server_extensions" -}}
+{{ if .ServerExtensions -}}
+##### --- Available extensions not installed in any database --- ####
+shared_preload_libraries: {{ .ServerExtensions.SharedPreloadLibraries }}
+{{ with .NotInstalledExtensions -}}
++--------------------------------+------------+------------------------------------------------------------+
+|           Extension            |  Default   |                          Comment                           |
++--------------------------------+------------+------------------------------------------------------------+
+{{ range . -}}` +
+	`| {{ printf "%-30s" (trim .Name 30) }} ` +
+	`| {{ printf "%-10s" .DefaultVersion }} ` +
+	`| {{ printf "%-58s" (trim .Comment 58) }} |` + "\n" +
+	`{{ end -}}
++--------------------------------+------------+------------------------------------------------------------+
+{{ else -}}
+All the available extensions are installed
+{{ end -}}
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "largest_tables" -}}
+##### --- Largest tables per database --- ####
+{{ range $dbname, $values := . -}}
+Database: {{ $dbname }}
+{{ if $values -}}
++------------------------------------------+------+--------------+------------+------------+------------+------------+
+|                  Table                   | Kind |     Rows     |    Heap    |   Indexes  |    TOAST   |    Total   |
++------------------------------------------+------+--------------+------------+------------+------------+------------+
+{{ range $values -}}` +
+	`| {{ printf "%-40s" (trim (printf "%s.%s" .Schemaname .Relname) 40) }} ` +
+	`|   {{ printf "%1s" .Relkind }}  ` +
+	`| {{ printf "% 12d" .RowEstimate }} ` +
+	`| {{ printf "%10s" (bytes .HeapSize) }} ` +
+	`| {{ printf "%10s" (bytes .IndexesSize) }} ` +
+	`| {{ printf "%10s" (bytes .ToastSize) }} ` +
+	`| {{ printf "%10s" (bytes .TotalSize) }} |` + "\n" +
+	`{{ end -}}
++------------------------------------------+------+--------------+------------+------------+------------+------------+
+{{ else -}}
+  No tables found
+{{ end -}}
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "vacuum_health" -}}
+##### --- Tables with dead tuples per database (overdue for vacuum first) --- ####
+AV Threshold is the dead tuples threshold. The inserts threshold of PostgreSQL 13+ is not considered.
+{{ range $dbname, $values := . -}}
+Database: {{ $dbname }}
+{{ if $values -}}
++------------------------------------------+--------------+--------------+---------+--------------+---------+---------------------+---------------------+---------------------+---------------------+-------------------+
+|                  Table                   |  Live Tuples |  Dead Tuples |  Dead % | AV Threshold | Overdue |     Last Vacuum     |   Last Autovacuum   |    Last Analyze     |  Last Autoanalyze   |  V / AV / A / AA  |
++------------------------------------------+--------------+--------------+---------+--------------+---------+---------------------+---------------------+---------------------+---------------------+-------------------+
+{{ range $values -}}` +
+	`| {{ printf "%-40s" (trim (printf "%s.%s" .Schemaname .Relname) 40) }} ` +
+	`| {{ printf "% 12d" .NLiveTup }} ` +
+	`| {{ printf "% 12d" .NDeadTup }} ` +
+	`| {{ printf "% 7.2f" (pct .DeadRatio) }} ` +
+	`| {{ printf "% 12d" .AutovacuumThreshold }} ` +
+	`| {{ if not .AutovacuumEnabled }}AV OFF {{ else if .Overdue }}  YES  {{ else }}   no  {{ end }} ` +
+	`| {{ template "nulltime" .LastVacuum }} ` +
+	`| {{ template "nulltime" .LastAutovacuum }} ` +
+	`| {{ template "nulltime" .LastAnalyze }} ` +
+	`| {{ template "nulltime" .LastAutoanalyze }} ` +
+	`| {{ printf "%17s" (printf "%d/%d/%d/%d" .VacuumCount .AutovacuumCount .AnalyzeCount .AutoanalyzeCount) }} |` + "\n" +
+	`{{ end -}}
++------------------------------------------+--------------+--------------+---------+--------------+---------+---------------------+---------------------+---------------------+---------------------+-------------------+
+{{ else -}}
+  There are no tables with dead tuples
+{{ end -}}
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "table_wraparound" -}}
+##### --- Tables with the oldest relfrozenxid per database --- ####
+{{ range $dbname, $values := . -}}
+Database: {{ $dbname }}
+{{ if $values -}}
++------------------------------------------+------+--------------+---------+--------------+---------+------------+--------------------+----------+
+|                  Table                   | Kind |    XID Age   |  XID %  |   MXID Age   |  MXID % |    Size    | Past Freeze MaxAge |  Status  |
++------------------------------------------+------+--------------+---------+--------------+---------+------------+--------------------+----------+
+{{ range $values -}}` +
+	`| {{ printf "%-40s" (trim (printf "%s.%s" .Schemaname .Relname) 40) }} ` +
+	`|   {{ printf "%1s" .Relkind }}  ` +
+	`| {{ printf "% 12d" .XidAge }} ` +
+	`| {{ printf "% 7.2f" .XidPct }} ` +
+	`| {{ printf "% 12d" .MxidAge }} ` +
+	`| {{ printf "% 7.2f" .MxidPct }} ` +
+	`| {{ printf "%10s" (bytes .Size) }} ` +
+	`| {{ printf "%-18v" .PastFreezeMaxAge }} ` +
+	`| {{ printf "%-8s" .Level }} |` + "\n" +
+	`{{ end -}}
++------------------------------------------+------+--------------+---------+--------------+---------+------------+--------------------+----------+
+{{ else -}}
+  No stats available
+{{ end -}}
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "sequences" -}}
+##### --- Sequences closer to exhaustion per database --- ####
+{{ range $dbname, $values := . -}}
+Database: {{ $dbname }}
+{{ if $values -}}
++------------------------------------------+------------------------------------------+----------+----------------------+----------------------+---------+------------+--------------+
+|                 Sequence                 |                 Owned by                 |   Type   |      Last Value      |        Limit         |  Used % |  Growth/s  |  Time Left   |
++------------------------------------------+------------------------------------------+----------+----------------------+----------------------+---------+------------+--------------+
+{{ range $values -}}` +
+	`| {{ printf "%-40s" (trim (printf "%s.%s" .Schemaname .Sequencename) 40) }} ` +
+	`| {{ printf "%-40s" (trim .OwnedBy 40) }} ` +
+	`| {{ printf "%-8s" .ColumnType }} ` +
+	`| {{ printf "% 20d" .LastValue }} ` +
+	`| {{ printf "% 20d" .Limit }} ` +
+	`| {{ printf "% 7.2f" (pct .UsedRatio) }} ` +
+	`| {{ printf "% 10.2f" .Growth }} ` +
+	`| {{ printf "%12s" .TimeLeft }} |` + "\n" +
+	`{{ end -}}
++------------------------------------------+------------------------------------------+----------+----------------------+----------------------+---------+------------+--------------+
+{{ else -}}
+  No sequences found
+{{ end -}}
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "bloat" -}}
+##### --- Top bloated tables and indexes per database --- ####
+{{ range $dbname, $bloat := . -}}
+Database: {{ $dbname }}
+Tables (estimated)
+{{ template "table_bloat" $bloat.Tables -}}
+Btree indexes (estimated)
+{{ if $bloat.Indexes -}}
++------------------------------------------+------------------------------------------+------------+------------+---------+-----+
+|                  Table                   |                  Index                   |    Size    |   Wasted   |    %    |  FF |
++------------------------------------------+------------------------------------------+------------+------------+---------+-----+
+{{ range $bloat.Indexes -}}` +
+	`| {{ printf "%-40s" (trim (printf "%s.%s" .Schemaname .Tblname) 40) }} ` +
+	`| {{ printf "%-40s" (trim .Idxname 40) }} ` +
+	`| {{ printf "%10s" (bytes .RealSize) }} ` +
+	`| {{ printf "%10s" (bytes .BloatSize) }} ` +
+	`| {{ printf "% 7.2f" .BloatPct }} ` +
+	`| {{ printf "% 3d" .Fillfactor }} |` + "\n" +
+	`{{ end -}}
++------------------------------------------+------------------------------------------+------------+------------+---------+-----+
+{{ else -}}
+  No bloated indexes found
+{{ end -}}
+{{ if $bloat.ExactTables -}}
+Largest tables (pgstattuple_approx)
+{{ template "table_bloat" $bloat.ExactTables -}}
+{{ end -}}
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "table_bloat" -}}
+{{ if . -}}
++------------------------------------------+------------+------------+---------+
+|                  Table                   |    Size    |   Wasted   |    %    |
++------------------------------------------+------------+------------+---------+
+{{ range . -}}` +
+	`| {{ printf "%-40s" (trim (printf "%s.%s" .Schemaname .Tblname) 40) }} ` +
+	`| {{ printf "%10s" (bytes .RealSize) }} ` +
+	`| {{ printf "%10s" (bytes .BloatSize) }} ` +
+	`| {{ printf "% 7.2f" .BloatPct }} |` + "\n" +
+	`{{ end -}}
++------------------------------------------+------------+------------+---------+
+{{ else -}}
+  No bloated tables found
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "index_health" -}}
+##### --- Index health per database --- ####
+{{ range $dbname, $health := . -}}
+Database: {{ $dbname }}
+Unused indexes (not unique, no scans since the last stats reset)
+{{ if $health.Unused -}}
++------------------------------------------+------------------------------------------+------------+
+|                  Table                   |                  Index                   |    Size    |
++------------------------------------------+------------------------------------------+------------+
+{{ range $health.Unused -}}` +
+	`| {{ printf "%-40s" (trim (printf "%s.%s" .Schemaname .Tblname) 40) }} ` +
+	`| {{ printf "%-40s" (trim .Idxname 40) }} ` +
+	`| {{ printf "%10s" (bytes .Size) }} |` + "\n" +
+	`{{ end -}}
++------------------------------------------+------------------------------------------+------------+
+{{ else -}}
+  No unused indexes found
+{{ end -}}
+Duplicate or redundant indexes
+{{ if $health.Redundant -}}
++------------------------------------------+------------------------------------------+------------------------------------------+------------+
+|                  Table                   |                  Index                   |               Covered by                 |    Size    |
++------------------------------------------+------------------------------------------+------------------------------------------+------------+
+{{ range $health.Redundant -}}` +
+	`| {{ printf "%-40s" (trim (printf "%s.%s" .Schemaname .Tblname) 40) }} ` +
+	`| {{ printf "%-40s" (trim .Idxname 40) }} ` +
+	`| {{ printf "%-40s" (trim .CoveringIdxname 40) }} ` +
+	`| {{ printf "%10s" (bytes .Size) }} |` + "\n" +
+	`  {{ .Indexdef }}` + "\n" +
+	`  {{ .CoveringIndexdef }}` + "\n" +
+	`{{ end -}}
++------------------------------------------+------------------------------------------+------------------------------------------+------------+
+{{ else -}}
+  No redundant indexes found
+{{ end -}}
+Invalid indexes
+{{ if $health.Invalid -}}
++------------------------------------------+------------------------------------------+------------+
+|                  Table                   |                  Index                   |    Size    |
++------------------------------------------+------------------------------------------+------------+
+{{ range $health.Invalid -}}` +
+	`| {{ printf "%-40s" (trim (printf "%s.%s" .Schemaname .Tblname) 40) }} ` +
+	`| {{ printf "%-40s" (trim .Idxname 40) }} ` +
+	`| {{ printf "%10s" (bytes .Size) }} |` + "\n" +
+	`  {{ .Indexdef }}` + "\n" +
+	`{{ end -}}
++------------------------------------------+------------------------------------------+------------+
+{{ else -}}
+  No invalid indexes found
+{{ end -}}
+Foreign keys without an index on the referencing columns
+{{ if $health.MissingFK -}}
++------------------------------------------+------------------------------------------+------------+
+|                  Table                   |                Constraint                | Table Size |
++------------------------------------------+------------------------------------------+------------+
+{{ range $health.MissingFK -}}` +
+	`| {{ printf "%-40s" (trim (printf "%s.%s" .Schemaname .Tblname) 40) }} ` +
+	`| {{ printf "%-40s" (trim .Conname 40) }} ` +
+	`| {{ printf "%10s" (bytes .TableSize) }} |` + "\n" +
+	`  {{ .Condef }}` + "\n" +
+	`{{ end -}}
++------------------------------------------+------------------------------------------+------------+
+{{ else -}}
+  No foreign keys without indexes found
+{{ end -}}
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "nulltime" -}}
+{{ if .Valid }}{{ .Time.Format "2006-01-02 15:04:05" }}{{ else }}{{ printf "%-19s" "never" }}{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "roles_audit" -}}
+##### --- Roles --- ####
+{{ if . -}}
+Superusers: {{ join .Superusers ", " }}
+Login roles without password expiration: {{ join .NoPasswordExpiry ", " }}
+{{ if .PasswordHashes -}}
+password_encryption: {{ .PasswordEncryption }}
+{{ if .MD5Passwords -}}
+WARNING: roles using MD5 passwords while password_encryption is scram-sha-256: {{ join .MD5Passwords ", " }}
+{{ end -}}
+{{ else -}}
+Password hashes not checked: pg_authid is not readable
+{{ end -}}
++--------------------------------+-------+-------+-------+-------+-------+-------+-------+---------------------+---------------+
+|              Role              | Super | CrRole| CrDB  | Login | Repl  | BypRLS| Inher |     Valid Until     |    Password   |
++--------------------------------+-------+-------+-------+-------+-------+-------+-------+---------------------+---------------+
+{{ range .Roles -}}` +
+	`| {{ printf "%-30s" (trim .Rolname 30) }} ` +
+	`| {{ template "yes_no" .Rolsuper }} ` +
+	`| {{ template "yes_no" .Rolcreaterole }} ` +
+	`| {{ template "yes_no" .Rolcreatedb }} ` +
+	`| {{ template "yes_no" .Rolcanlogin }} ` +
+	`| {{ template "yes_no" .Rolreplication }} ` +
+	`| {{ template "yes_no" .Rolbypassrls }} ` +
+	`| {{ template "yes_no" .Rolinherit }} ` +
+	`| {{ template "nulltime" .Rolvaliduntil }} ` +
+	`| {{ printf "%-13s" .PasswordType }} |` + "\n" +
+	`{{ end -}}
++--------------------------------+-------+-------+-------+-------+-------+-------+-------+---------------------+---------------+
+{{ if .Memberships -}}
+Role memberships:
++--------------------------------+--------------------------------+-------+--------------------------------+
+|              Role              |           Member of            | Admin |            Grantor             |
++--------------------------------+--------------------------------+-------+--------------------------------+
+{{ range .Memberships -}}` +
+	`| {{ printf "%-30s" .Rolname }} ` +
+	`| {{ printf "%-30s" .MemberOf }} ` +
+	`| {{ template "yes_no" .AdminOption }} ` +
+	`| {{ printf "%-30s" .Grantor }} |` + "\n" +
+	`{{ end -}}
++--------------------------------+--------------------------------+-------+--------------------------------+
+{{ end -}}
+{{ else -}}
+No stats available
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "yes_no" -}}
+{{ if . }} YES {{ else }}     {{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "public_schema" -}}
+##### --- CREATE privilege for PUBLIC on the public schema --- ####
+{{ range $dbname, $privileges := . -}}
+Database: {{ printf "%-30s" $dbname }} {{ if not .SchemaExists }}no public schema{{ else if .PublicCreate }}WARNING: any role can create objects{{ else }}revoked{{ end }}
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
+	`{{ define "hba_rules" -}}
+##### --- pg_hba.conf rules --- ####
+{{ if . -}}
+Source: {{ .Source }}
++--------------------------+--------------+----------------------+----------------------+---------------------------+---------------+---------
+|           Line           |     Type     |      Databases       |        Users         |          Address          |    Method     | Findings
++--------------------------+--------------+----------------------+----------------------+---------------------------+---------------+---------
+{{ range .Rules -}}` +
+	`| {{ printf "%-24s" (trim .Location 24) }} ` +
+	`| {{ printf "%-12s" .Type }} ` +
+	`| {{ printf "%-20s" (trim (join .Databases ",") 20) }} ` +
+	`| {{ printf "%-20s" (trim (join .Users ",") 20) }} ` +
+	`| {{ printf "%-25s" (trim .Address 25) }} ` +
+	`| {{ printf "%-13s" .Method }} ` +
+	`| {{ if .Error }}ERROR: {{ .Error }}{{ else }}{{ join .Warnings ", " }}{{ if .ShadowedBy }}{{ if .Warnings }}, {{ end }}shadowed by {{ .ShadowedBy }}{{ end }}{{ end }}` + "\n" +
+	`{{ end -}}
++--------------------------+--------------+----------------------+----------------------+---------------------------+---------------+---------
+{{ else -}}
+pg_hba.conf rules are not available
+{{ end -}}
+{{ end -}} {{/* end define */}}
+` +
 	`{{ define "settings" -}}` +
 	/*
 	   Settings
 	*/
-	"##### --- Instance settings --- ####\n" +
-	"                      Setting                 " +
-	"                           Value                     \n" +
-	`{{ range $name, $values := . -}}` +
-	` {{ printf "%-45s" .Name }} ` +
-	`: {{ printf "%-60s" .Setting }}  ` +
-	"\n" +
-	"{{ end }}" +
-	"{{ end }}" +
+	`{{ if . -}}
+##### --- Instance settings{{ if not .All }} different from their boot value{{ end }} --- ####
+{{ if .PendingRestart -}}
+WARNING: settings waiting for a restart to be applied: {{ join .PendingRestart ", " }}
+{{ end -}}
+{{ range .Categories -}}
+[{{ .Name }}]
+{{ range .Settings -}}
+` +
+	`{{ printf " %-45s" .Name }} ` +
+	`: {{ printf "%-30s" .Value }} ` +
+	`(default: {{ .BootValue }}{{ if ne .ResetValue .Value }}, reset: {{ .ResetValue }}{{ end }}, source: {{ .Origin }})` +
+	`{{ if .PendingRestart }} PENDING RESTART{{ end }}` + "\n" +
+	`{{ end -}}
+{{ end -}}
+{{ else -}}
+##### --- Instance settings --- ####
+No stats available
+{{ end -}}
+{{ end }}` +
 	/*
 	   Processes
 	*/